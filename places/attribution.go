@@ -0,0 +1,90 @@
+package places
+
+// Attributions collects the attributions that the terms of the API require to be displayed alongside the data they apply to, from responses, places and photos, listing each once. Attributions are HTML; use HTML or Text to display them safely. The zero value is empty and ready to use.
+//
+//	var a places.Attributions
+//	a.AddSearch(resp)
+//	for _, attribution := range a.HTML() { ... }
+type Attributions struct {
+	seen map[string]bool
+	list []string
+}
+
+// Add adds attributions, ignoring any that render the same as one already added or that render as nothing.
+func (a *Attributions) Add(attributions ...string) {
+	for _, attribution := range attributions {
+		key := normalizeSpace(SanitizeHTML(attribution))
+		if key == "" || a.seen[key] {
+			continue
+		}
+		if a.seen == nil {
+			a.seen = map[string]bool{}
+		}
+		a.seen[key] = true
+		a.list = append(a.list, attribution)
+	}
+}
+
+// AddSearch adds the attributions of a search response and of the photos of its results.
+func (a *Attributions) AddSearch(resp *SearchResponse) {
+	a.Add(resp.HTMLAttributions...)
+	for i := range resp.Results {
+		a.AddPlace(&resp.Results[i])
+	}
+}
+
+// AddDetails adds the attributions of a Details response and of the photos of its result.
+func (a *Attributions) AddDetails(resp *DetailsResponse) {
+	a.Add(resp.HTMLAttributions...)
+	a.AddPlace(&resp.Result)
+}
+
+// AddPlace adds the attributions of the photos of a place.
+func (a *Attributions) AddPlace(p *PlaceDetails) {
+	for _, photo := range p.Photos {
+		a.AddPhoto(photo)
+	}
+}
+
+// AddPhoto adds the attributions of a photo.
+func (a *Attributions) AddPhoto(p Photo) {
+	a.Add(p.HTMLAttributions...)
+}
+
+// Len returns the number of attributions collected.
+func (a *Attributions) Len() int {
+	return len(a.list)
+}
+
+// Raw returns the attributions in the order they were added, as they were received. They must not be included in a web page without being sanitized.
+func (a *Attributions) Raw() []string {
+	return append([]string(nil), a.list...)
+}
+
+// HTML returns the attributions in the order they were added, sanitized by SanitizeHTML.
+func (a *Attributions) HTML() []string {
+	return a.render(SanitizeHTML)
+}
+
+// Text returns the attributions in the order they were added, as plain text produced by HTMLToText.
+func (a *Attributions) Text() []string {
+	return a.render(HTMLToText)
+}
+
+func (a *Attributions) render(fn func(string) string) []string {
+	rendered := make([]string, len(a.list))
+	for i, attribution := range a.list {
+		rendered[i] = fn(attribution)
+	}
+	return rendered
+}
+
+// SafeHTML returns the text of the review sanitized by SanitizeHTML, ready to include in a web page.
+func (r *Review) SafeHTML() string {
+	return SanitizeHTML(r.Text)
+}
+
+// PlainText returns the text of the review without markup, as produced by HTMLToText.
+func (r *Review) PlainText() string {
+	return HTMLToText(r.Text)
+}
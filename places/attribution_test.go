@@ -0,0 +1,50 @@
+package places
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAttributions(t *testing.T) {
+	link := `<a href="https://maps.google.com/maps/contrib/1">Ann</a>`
+	search := &SearchResponse{
+		HTMLAttributions: []string{"Listings by <b>Yellow Pages</b>"},
+		Results: []PlaceDetails{
+			{Photos: []Photo{{HTMLAttributions: []string{link}}, {HTMLAttributions: []string{`<a href='https://maps.google.com/maps/contrib/1'>Ann</a>`}}}},
+			{Photos: []Photo{{HTMLAttributions: []string{`<a href="https://maps.google.com/maps/contrib/2" onclick="steal()">Bob</a>`}}}},
+		},
+	}
+	details := &DetailsResponse{
+		HTMLAttributions: []string{"Listings by  <b>Yellow Pages</b>", "  ", "<script>alert(1)</script>"},
+		Result:           PlaceDetails{Photos: []Photo{{HTMLAttributions: []string{link}}}},
+	}
+
+	var a Attributions
+	a.AddSearch(search)
+	a.AddDetails(details)
+	a.AddPhoto(Photo{HTMLAttributions: []string{"Photo &copy; Cat"}})
+
+	if a.Len() != 4 {
+		t.Errorf("Len() = %d, want 4: %q", a.Len(), a.Raw())
+	}
+	wantHTML := `[Listings by <b>Yellow Pages</b> <a href="https://maps.google.com/maps/contrib/1" rel="nofollow noopener">Ann</a> <a href="https://maps.google.com/maps/contrib/2" rel="nofollow noopener">Bob</a> Photo © Cat]`
+	if got := fmt.Sprint(a.HTML()); got != wantHTML {
+		t.Errorf("HTML() = %s, want %s", got, wantHTML)
+	}
+	if got, want := fmt.Sprintf("%q", a.Text()), `["Listings by Yellow Pages" "Ann" "Bob" "Photo © Cat"]`; got != want {
+		t.Errorf("Text() = %s, want %s", got, want)
+	}
+	if got := a.Raw()[2]; got != search.Results[1].Photos[0].HTMLAttributions[0] {
+		t.Errorf("Raw() = %q, want the attribution as received", got)
+	}
+}
+
+func TestReviewText(t *testing.T) {
+	r := &Review{Text: "Best <b>pizza</b> &amp; pasta<img src=x onerror=alert(1)>!"}
+	if got, want := r.SafeHTML(), "Best <b>pizza</b> &amp; pasta!"; got != want {
+		t.Errorf("SafeHTML() = %q, want %q", got, want)
+	}
+	if got, want := r.PlainText(), "Best pizza & pasta!"; got != want {
+		t.Errorf("PlainText() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,283 @@
+package places
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BreakerState is the state of the circuit breaker for one endpoint.
+type BreakerState int
+
+const (
+	// BreakerClosed lets every request through while the breaker measures the error rate.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails every request immediately without contacting the API.
+	BreakerOpen
+	// BreakerHalfOpen lets a limited number of trial requests through to find out whether the API has recovered.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("BreakerState(%d)", int(s))
+}
+
+// BreakerSettings configures a CircuitBreaker. Zero values are replaced by the defaults noted on each field.
+type BreakerSettings struct {
+	// The period over which the error rate is measured. Defaults to one minute.
+	Window time.Duration
+	// The minimum number of requests in the window before the breaker may open. Defaults to 20.
+	MinRequests int
+	// The fraction of failed requests, from 0 to 1, at which the breaker opens. Defaults to 0.5.
+	ErrorRate float64
+	// How long the breaker stays open before letting trial requests through. Defaults to 30 seconds.
+	OpenTimeout time.Duration
+	// The number of consecutive successful trial requests needed to close the breaker again. Defaults to 1.
+	HalfOpenRequests int
+	// Called, without any locks held, whenever an endpoint's breaker changes state.
+	OnStateChange func(endpoint string, from, to BreakerState)
+}
+
+// breakerBuckets is the number of slices the window is divided into. Old slices are discarded as the window slides.
+const breakerBuckets = 10
+
+// A CircuitBreaker stops a Service from sending requests to an endpoint that keeps failing, e.g. because the key has been revoked (REQUEST_DENIED) or the backend returns 5xx errors. Each endpoint has its own breaker state.
+//
+// A CircuitBreaker is safe for concurrent use.
+type CircuitBreaker struct {
+	settings BreakerSettings
+
+	mu        sync.Mutex
+	endpoints map[string]*endpointBreaker
+
+	now func() time.Time
+}
+
+type endpointBreaker struct {
+	state    BreakerState
+	openedAt time.Time
+
+	// buckets hold the request and failure counts for consecutive slices of the window, the last being the current one.
+	buckets  [breakerBuckets]breakerBucket
+	bucketAt time.Time
+
+	// trials is the number of trial requests in flight while half-open and successes the number that have succeeded.
+	trials    int
+	successes int
+}
+
+type breakerBucket struct {
+	requests, failures int
+}
+
+// breakerError is returned while the breaker for an endpoint is open.
+type breakerError struct {
+	Endpoint string
+}
+
+func (e *breakerError) Error() string {
+	return fmt.Sprintf("circuit breaker is open for %s", e.Endpoint)
+}
+
+// IsCircuitOpen returns true if the request was not sent because the circuit breaker for its endpoint is open.
+func IsCircuitOpen(err error) bool {
+	_, ok := err.(*breakerError)
+	return ok
+}
+
+// NewCircuitBreaker creates a circuit breaker with the given settings.
+func NewCircuitBreaker(settings BreakerSettings) *CircuitBreaker {
+	if settings.Window <= 0 {
+		settings.Window = time.Minute
+	}
+	if settings.MinRequests <= 0 {
+		settings.MinRequests = 20
+	}
+	if settings.ErrorRate <= 0 {
+		settings.ErrorRate = 0.5
+	}
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = 30 * time.Second
+	}
+	if settings.HalfOpenRequests <= 0 {
+		settings.HalfOpenRequests = 1
+	}
+	return &CircuitBreaker{
+		settings:  settings,
+		endpoints: make(map[string]*endpointBreaker),
+		now:       time.Now,
+	}
+}
+
+// State returns the current state of the breaker for an endpoint such as "nearbysearch" or "details".
+func (b *CircuitBreaker) State(endpoint string) BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	e, ok := b.endpoints[endpoint]
+	if !ok {
+		return BreakerClosed
+	}
+	if e.state == BreakerOpen && !b.now().Before(e.openedAt.Add(b.settings.OpenTimeout)) {
+		return BreakerHalfOpen
+	}
+	return e.state
+}
+
+// allow reports whether a request to endpoint may be sent, and whether it is sent as one of the trial requests of a half-open breaker. Every allowed request must be followed by a call to done.
+func (b *CircuitBreaker) allow(endpoint string) (trial bool, err error) {
+	b.mu.Lock()
+	e := b.endpoint(endpoint)
+	from := e.state
+
+	switch e.state {
+	case BreakerOpen:
+		if b.now().Before(e.openedAt.Add(b.settings.OpenTimeout)) {
+			b.mu.Unlock()
+			return false, &breakerError{Endpoint: endpoint}
+		}
+		e.state = BreakerHalfOpen
+		e.trials, e.successes = 0, 0
+		fallthrough
+	case BreakerHalfOpen:
+		if e.trials >= b.settings.HalfOpenRequests {
+			b.mu.Unlock()
+			b.notify(endpoint, from, e.state)
+			return false, &breakerError{Endpoint: endpoint}
+		}
+		e.trials++
+		trial = true
+	}
+	to := e.state
+	b.mu.Unlock()
+
+	b.notify(endpoint, from, to)
+	return trial, nil
+}
+
+// done records the outcome of a request that was allowed through, trial being the value allow returned for it. Requests that failed on the client side, e.g. because their context was cancelled, count neither as successes nor as failures.
+func (b *CircuitBreaker) done(endpoint string, trial bool, err error) {
+	failed := isBackendFailure(err)
+
+	b.mu.Lock()
+	e := b.endpoint(endpoint)
+	from := e.state
+
+	// Only trials release a slot, and only while the breaker is still half-open: tripping has already reset the count.
+	if trial && e.state == BreakerHalfOpen && e.trials > 0 {
+		e.trials--
+	} else {
+		trial = false
+	}
+
+	switch {
+	case isClientFailure(err):
+	case e.state == BreakerClosed:
+		b.advance(e)
+		bucket := &e.buckets[breakerBuckets-1]
+		bucket.requests++
+		if failed {
+			bucket.failures++
+		}
+		requests, failures := e.totals()
+		if requests >= b.settings.MinRequests && float64(failures)/float64(requests) >= b.settings.ErrorRate {
+			b.trip(e)
+		}
+	case e.state == BreakerHalfOpen && trial:
+		if failed {
+			b.trip(e)
+			break
+		}
+		e.successes++
+		if e.successes >= b.settings.HalfOpenRequests {
+			e.state = BreakerClosed
+			e.buckets = [breakerBuckets]breakerBucket{}
+		}
+	}
+	to := e.state
+	b.mu.Unlock()
+
+	b.notify(endpoint, from, to)
+}
+
+func (b *CircuitBreaker) endpoint(name string) *endpointBreaker {
+	e, ok := b.endpoints[name]
+	if !ok {
+		e = &endpointBreaker{bucketAt: b.now()}
+		b.endpoints[name] = e
+	}
+	return e
+}
+
+func (b *CircuitBreaker) trip(e *endpointBreaker) {
+	e.state = BreakerOpen
+	e.openedAt = b.now()
+	e.trials, e.successes = 0, 0
+}
+
+// advance slides the window forward to the current time, discarding buckets that have fallen out of it.
+func (b *CircuitBreaker) advance(e *endpointBreaker) {
+	width := b.settings.Window / breakerBuckets
+	if width <= 0 {
+		width = 1
+	}
+	shift := int(b.now().Sub(e.bucketAt) / width)
+	if shift <= 0 {
+		return
+	}
+	if shift >= breakerBuckets {
+		e.buckets = [breakerBuckets]breakerBucket{}
+	} else {
+		copy(e.buckets[:], e.buckets[shift:])
+		for i := breakerBuckets - shift; i < breakerBuckets; i++ {
+			e.buckets[i] = breakerBucket{}
+		}
+	}
+	e.bucketAt = e.bucketAt.Add(time.Duration(shift) * width)
+}
+
+func (e *endpointBreaker) totals() (requests, failures int) {
+	for _, bucket := range e.buckets {
+		requests += bucket.requests
+		failures += bucket.failures
+	}
+	return requests, failures
+}
+
+func (b *CircuitBreaker) notify(endpoint string, from, to BreakerState) {
+	if from != to && b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(endpoint, from, to)
+	}
+}
+
+// isClientFailure reports whether err was caused on the client side before or while the request was sent, in which case it says nothing about the health of the API.
+func isClientFailure(err error) bool {
+	if _, ok := err.(*limiterError); ok {
+		return true
+	}
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || err == errNoAvailableKeys
+}
+
+// isBackendFailure reports whether err means the API is unusable, as opposed to a problem with an individual request.
+func isBackendFailure(err error) bool {
+	switch err.(type) {
+	case nil:
+		return false
+	case *apiError:
+		return IsRequestDenied(err) || IsUnknown(err)
+	case *httpError:
+		return IsServerError(err)
+	}
+	// Transport errors such as refused connections.
+	return !isClientFailure(err)
+}
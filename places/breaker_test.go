@@ -0,0 +1,195 @@
+package places
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerStates(t *testing.T) {
+	now := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var changes []string
+	breaker := NewCircuitBreaker(BreakerSettings{
+		Window:      time.Minute,
+		MinRequests: 4,
+		ErrorRate:   0.5,
+		OpenTimeout: 10 * time.Second,
+		OnStateChange: func(endpoint string, from, to BreakerState) {
+			changes = append(changes, fmt.Sprintf("%s:%v->%v", endpoint, from, to))
+		},
+	})
+	breaker.now = func() time.Time { return now }
+
+	denied := &apiError{Status: "REQUEST_DENIED"}
+	for i, outcome := range []error{nil, denied, nil, denied} {
+		trial, err := breaker.allow("details")
+		if err != nil {
+			t.Fatalf("allow() #%d = %v, want nil", i, err)
+		}
+		breaker.done("details", trial, outcome)
+	}
+
+	if got := breaker.State("details"); got != BreakerOpen {
+		t.Fatalf("State() after 50%% errors = %v, want open", got)
+	}
+	if got := breaker.State("nearbysearch"); got != BreakerClosed {
+		t.Errorf("State() of untouched endpoint = %v, want closed", got)
+	}
+	if _, err := breaker.allow("details"); !IsCircuitOpen(err) {
+		t.Errorf("allow() while open = %v, want breaker error", err)
+	}
+
+	now = now.Add(10 * time.Second)
+	trial, err := breaker.allow("details")
+	if err != nil {
+		t.Fatalf("allow() after timeout = %v, want nil", err)
+	}
+	if _, err := breaker.allow("details"); !IsCircuitOpen(err) {
+		t.Errorf("second allow() while half-open = %v, want breaker error", err)
+	}
+	breaker.done("details", trial, &httpError{StatusCode: 503})
+	if got := breaker.State("details"); got != BreakerOpen {
+		t.Fatalf("State() after failed trial = %v, want open", got)
+	}
+
+	now = now.Add(10 * time.Second)
+	trial, err = breaker.allow("details")
+	if err != nil {
+		t.Fatalf("allow() after second timeout = %v, want nil", err)
+	}
+	breaker.done("details", trial, &apiError{Status: "ZERO_RESULTS"})
+	if got := breaker.State("details"); got != BreakerClosed {
+		t.Fatalf("State() after successful trial = %v, want closed", got)
+	}
+
+	want := "[details:closed->open details:open->half-open details:half-open->open details:open->half-open details:half-open->closed]"
+	if fmt.Sprint(changes) != want {
+		t.Errorf("state changes = %v, want %v", changes, want)
+	}
+}
+
+func TestCircuitBreakerWindow(t *testing.T) {
+	now := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(BreakerSettings{
+		Window:      time.Minute,
+		MinRequests: 2,
+		ErrorRate:   0.6,
+	})
+	breaker.now = func() time.Time { return now }
+
+	trial, _ := breaker.allow("details")
+	breaker.done("details", trial, &httpError{StatusCode: 500})
+
+	// The first failure has left the window by the time the second arrives, so only one in two requests failed.
+	now = now.Add(2 * time.Minute)
+	for _, outcome := range []error{nil, &httpError{StatusCode: 500}} {
+		trial, _ := breaker.allow("details")
+		breaker.done("details", trial, outcome)
+	}
+	if got := breaker.State("details"); got != BreakerClosed {
+		t.Errorf("State() = %v, want closed", got)
+	}
+
+	now = now.Add(2 * time.Minute)
+	breaker = NewCircuitBreaker(BreakerSettings{MinRequests: 2})
+	breaker.now = func() time.Time { return now }
+	for _, outcome := range []error{&apiError{Status: "INVALID_REQUEST"}, &apiError{Status: "NOT_FOUND"}} {
+		trial, _ := breaker.allow("details")
+		breaker.done("details", trial, outcome)
+	}
+	if got := breaker.State("details"); got != BreakerClosed {
+		t.Errorf("State() after client errors = %v, want closed", got)
+	}
+}
+
+func TestCircuitBreakerClientFailures(t *testing.T) {
+	breaker := NewCircuitBreaker(BreakerSettings{MinRequests: 2, ErrorRate: 1})
+
+	timeout := &url.Error{Op: "Get", URL: "https://maps.googleapis.com", Err: context.DeadlineExceeded}
+	for _, outcome := range []error{context.Canceled, timeout, &limiterError{errors.New("rate: Wait(n=1) exceeds limiter's burst 0")}, errNoAvailableKeys} {
+		trial, _ := breaker.allow("details")
+		breaker.done("details", trial, outcome)
+		if got := breaker.State("details"); got != BreakerClosed {
+			t.Fatalf("State() after %v = %v, want closed", outcome, got)
+		}
+	}
+
+	// Had the client failures counted as successful requests, two failures in six would stay under the error rate.
+	for _, outcome := range []error{&httpError{StatusCode: 500}, &httpError{StatusCode: 502}} {
+		trial, _ := breaker.allow("details")
+		breaker.done("details", trial, outcome)
+	}
+	if got := breaker.State("details"); got != BreakerOpen {
+		t.Errorf("State() after two server errors = %v, want open", got)
+	}
+}
+
+func TestCircuitBreakerTrials(t *testing.T) {
+	now := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(BreakerSettings{MinRequests: 1, OpenTimeout: 10 * time.Second})
+	breaker.now = func() time.Time { return now }
+
+	// slow is allowed while the breaker is closed and only finishes once it is half-open.
+	slow, _ := breaker.allow("details")
+	trial, _ := breaker.allow("details")
+	breaker.done("details", trial, &httpError{StatusCode: 503})
+
+	now = now.Add(10 * time.Second)
+	trial, err := breaker.allow("details")
+	if err != nil || !trial {
+		t.Fatalf("allow() after timeout = %v, %v, want a trial", trial, err)
+	}
+	breaker.done("details", slow, nil)
+	if got := breaker.State("details"); got != BreakerHalfOpen {
+		t.Errorf("State() after a request allowed while closed succeeded = %v, want half-open", got)
+	}
+	if _, err := breaker.allow("details"); !IsCircuitOpen(err) {
+		t.Errorf("allow() with the trial in flight = %v, want breaker error", err)
+	}
+
+	// A cancelled trial frees its slot without closing or reopening the breaker.
+	breaker.done("details", trial, context.Canceled)
+	if got := breaker.State("details"); got != BreakerHalfOpen {
+		t.Errorf("State() after a cancelled trial = %v, want half-open", got)
+	}
+	if trial, err = breaker.allow("details"); err != nil || !trial {
+		t.Fatalf("allow() after a cancelled trial = %v, %v, want a trial", trial, err)
+	}
+	breaker.done("details", trial, nil)
+	if got := breaker.State("details"); got != BreakerClosed {
+		t.Errorf("State() after a successful trial = %v, want closed", got)
+	}
+}
+
+func TestServiceCircuitBreaker(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"status": "REQUEST_DENIED", "error_message": "The provided API key is invalid."}`)
+	}))
+	defer ts.Close()
+
+	service := NewService(http.DefaultClient, "revoked",
+		WithBaseURL(ts.URL),
+		WithCircuitBreaker(NewCircuitBreaker(BreakerSettings{MinRequests: 3})),
+	)
+
+	for i := 0; i < 5; i++ {
+		_, err := service.Details("place").Do()
+		if i < 3 && !IsRequestDenied(err) {
+			t.Errorf("Do() #%d = %v, want REQUEST_DENIED", i, err)
+		}
+		if i >= 3 && !IsCircuitOpen(err) {
+			t.Errorf("Do() #%d = %v, want breaker error", i, err)
+		}
+	}
+	if requests != 3 {
+		t.Errorf("server received %d requests, want 3", requests)
+	}
+}
@@ -0,0 +1,88 @@
+package places
+
+import (
+	"sync"
+	"time"
+)
+
+// A Cache stores the raw bodies of successful responses, keyed by endpoint and parameters. Credentials are never part of the key. Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, body []byte)
+}
+
+// MemoryCache is a Cache that keeps responses in memory for a fixed time.
+type MemoryCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+
+	now func() time.Time
+}
+
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+// NewMemoryCache creates a cache that keeps responses for ttl. Once it holds maxEntries responses, expired ones are dropped and, if that is not enough, the one closest to expiry; a maxEntries of 0 means no limit.
+func NewMemoryCache(ttl time.Duration, maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]cacheEntry),
+		now:        time.Now,
+	}
+}
+
+// Get implements Cache.
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return e.body, true
+}
+
+// Set implements Cache.
+func (c *MemoryCache) Set(key string, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if _, ok := c.entries[key]; !ok && c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		c.evict(now)
+	}
+	c.entries[key] = cacheEntry{body: body, expires: now.Add(c.ttl)}
+}
+
+// evict makes room for one more entry.
+func (c *MemoryCache) evict(now time.Time) {
+	var oldest string
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+		} else if oldest == "" || e.expires.Before(c.entries[oldest].expires) {
+			oldest = k
+		}
+	}
+	if len(c.entries) >= c.maxEntries {
+		delete(c.entries, oldest)
+	}
+}
+
+// Len returns the number of responses in the cache, including expired ones that have not been dropped yet.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
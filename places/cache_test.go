@@ -0,0 +1,82 @@
+package places
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache(t *testing.T) {
+	now := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewMemoryCache(time.Minute, 2)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("a", []byte("1"))
+	now = now.Add(time.Second)
+	cache.Set("b", []byte("2"))
+	now = now.Add(time.Second)
+	cache.Set("c", []byte("3"))
+
+	if _, ok := cache.Get("a"); ok {
+		t.Error("MemoryCache{}.Get(a) after eviction = true, want false")
+	}
+	if body, ok := cache.Get("c"); !ok || string(body) != "3" {
+		t.Errorf("MemoryCache{}.Get(c) = %q, %v, want 3, true", body, ok)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := cache.Get("c"); ok {
+		t.Error("MemoryCache{}.Get(c) after expiry = true, want false")
+	}
+	if cache.Len() != 1 {
+		t.Errorf("MemoryCache{}.Len() = %d, want 1", cache.Len())
+	}
+}
+
+func TestServiceCache(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("placeid") == "missing" {
+			fmt.Fprint(w, `{"status": "NOT_FOUND"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status": "OK", "result": {"place_id": "place", "name": "Google"}}`)
+	}))
+	defer ts.Close()
+
+	metrics := NewMetrics()
+	cache := NewMemoryCache(time.Minute, 0)
+	service := NewService(http.DefaultClient, "key",
+		WithBaseURL(ts.URL),
+		WithCache(cache),
+		WithCollector(metrics),
+	)
+
+	for i := 0; i < 3; i++ {
+		resp, err := service.Details("place").Do()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Result.Name != "Google" {
+			t.Errorf("DetailsCall{}.Do() #%d name = %q, want Google", i, resp.Result.Name)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := service.Details("missing").Do(); !IsNotFound(err) {
+			t.Errorf("DetailsCall{}.Do() = %v, want NOT_FOUND", err)
+		}
+	}
+
+	if requests != 3 {
+		t.Errorf("server received %d requests, want 3", requests)
+	}
+	if _, ok := cache.Get("details?placeid=place"); !ok {
+		t.Error("response is not cached under details?placeid=place")
+	}
+	if got := metrics.CacheHitRatio("details"); got != 0.4 {
+		t.Errorf("CacheHitRatio() = %v, want 0.4", got)
+	}
+}
@@ -0,0 +1,370 @@
+package places
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+)
+
+var (
+	errShortRoute      = errors.New("a route needs at least two points")
+	errInvalidBuffer   = errors.New("buffer must be greater than zero")
+	errNegativeSpacing = errors.New("interval must not be negative")
+	errTooManySearches = errors.New("the route needs more searches than MaxSearches allows; shorten it, widen the buffer or the interval, or raise MaxSearches")
+)
+
+const (
+	// defaultCorridorConcurrency is the number of searches a CorridorCall runs at once unless MaxConcurrency is set.
+	defaultCorridorConcurrency = 4
+	// defaultCorridorMaxSearches is the number of searches a CorridorCall may send unless MaxSearches is set.
+	defaultCorridorMaxSearches = 100
+)
+
+// Corridor searches for places within buffer meters of a route, such as the decoded overview polyline of a driving route, e.g. to find gas stations and restaurants along the way. Nearby Searches are sent from points sampled along the route, one for each of types at each point, or a single untyped search per point if no types are given. Only places within buffer of the route are kept, ordered by how far along the route they are.
+func (p *Service) Corridor(route []LatLng, buffer float64, types ...FeatureType) *CorridorCall {
+	return &CorridorCall{
+		service: p,
+		route:   route,
+		buffer:  buffer,
+		types:   types,
+	}
+}
+
+// CorridorCall represents the Nearby Search calls made along a route, whose results are merged.
+//
+// Every search is a billed request, and so is every further page of its results. A call sends one search per type at points every Interval meters, so a 500 km route with a 500 m buffer and three types needs about 1 500 searches. Do fails validation instead of sending more than MaxSearches.
+type CorridorCall struct {
+	service *Service
+	ctx     context.Context
+
+	// The points of the route, in order.
+	route []LatLng
+	// The greatest distance in meters from the route of the places to return.
+	buffer float64
+	// The types to search for. Duplicates are searched once.
+	types []FeatureType
+
+	// A term to be matched against all content that Google has indexed for this place, including but not limited to name, type, and address, as well as customer reviews and other third-party content.
+	Keyword string
+	// The language code, indicating in which language the results should be returned, if possible. Defaults to the service's language.
+	Language string
+	// Restricts results to only those places within the specified price level.
+	MinPrice, MaxPrice *PriceLevel
+	// One or more terms to be matched against the names of places, separated with a space character.
+	Name string
+	// Returns only those places that are open for business at the time the query is sent.
+	OpenNow bool
+	// Restricts the search to locations that are Zagat selected businesses.
+	ZagatSelected bool
+	// Interval is the distance in meters along the route between search points. Zero uses twice the buffer. Each search covers a circle just large enough to reach the edge of the corridor halfway to the next point, so longer intervals mean fewer but wider searches; the radius must not exceed 50 000 meters.
+	Interval float64
+	// Limits how many pages of results are fetched for each search. Zero fetches every page, of which the API returns at most three.
+	MaxPages int
+	// Limits how many searches run at once. Zero means 4.
+	MaxConcurrency int
+	// Limits how many searches Do may send, not counting further pages. Zero means 100; a negative value removes the limit.
+	MaxSearches int
+}
+
+// CorridorResponse holds the merged results of a CorridorCall.
+type CorridorResponse struct {
+	// The places within the buffer of the route, each listed once, in order of Offset.
+	Results []CorridorResult
+}
+
+// CorridorResult is a place found by a CorridorCall.
+type CorridorResult struct {
+	PlaceDetails
+	// MatchedTypes lists the requested types whose searches returned the place, in the order the types were requested.
+	MatchedTypes []FeatureType `json:"matched_types,omitempty"`
+	// Offset is the distance in meters along the route from its start to the point on the route nearest the place.
+	Offset float64 `json:"offset"`
+	// Distance is the distance in meters from the route to the place.
+	Distance float64 `json:"distance"`
+}
+
+// UnmarshalJSON decodes the place and the fields added by the corridor search, which the embedded PlaceDetails would otherwise decode on its own.
+func (r *CorridorResult) UnmarshalJSON(data []byte) error {
+	var extra struct {
+		MatchedTypes []FeatureType `json:"matched_types"`
+		Offset       float64       `json:"offset"`
+		Distance     float64       `json:"distance"`
+	}
+	if err := unmarshalResult(data, &r.PlaceDetails, &extra); err != nil {
+		return err
+	}
+	r.MatchedTypes, r.Offset, r.Distance = extra.MatchedTypes, extra.Offset, extra.Distance
+	return nil
+}
+
+// Context sets the context used by Do. Cancelling it aborts every search.
+func (c *CorridorCall) Context(ctx context.Context) *CorridorCall {
+	c.ctx = ctx
+	return c
+}
+
+// interval returns the distance between search points.
+func (c *CorridorCall) interval() float64 {
+	if c.Interval > 0 {
+		return c.Interval
+	}
+	return 2 * c.buffer
+}
+
+// radius returns the search radius that covers the corridor between search points.
+func (c *CorridorCall) radius() float64 {
+	return math.Ceil(math.Hypot(c.buffer, c.interval()/2))
+}
+
+// calls returns a Nearby Search call for each distinct type at each search point, after validating them.
+func (c *CorridorCall) calls() ([]*NearbyCall, error) {
+	var v validator
+	if len(c.route) < 2 {
+		v.check("route", errShortRoute)
+	}
+	for _, point := range c.route {
+		if err := NewLocation(point.Lat, point.Lng).validate(); err != nil {
+			v.check("route", err)
+			break
+		}
+	}
+	if c.buffer <= 0 {
+		v.check("buffer", errInvalidBuffer)
+	}
+	if c.Interval < 0 {
+		v.check("interval", errNegativeSpacing)
+	}
+
+	types := []FeatureType{""}
+	if len(c.types) > 0 {
+		types = nil
+		seen := map[FeatureType]bool{}
+		for _, t := range c.types {
+			if !seen[t] {
+				seen[t] = true
+				types = append(types, t)
+			}
+		}
+	}
+
+	template := NearbyCall{
+		service:       c.service,
+		Keyword:       c.Keyword,
+		Language:      c.Language,
+		MinPrice:      c.MinPrice,
+		MaxPrice:      c.MaxPrice,
+		Name:          c.Name,
+		OpenNow:       c.OpenNow,
+		Radius:        c.radius(),
+		ZagatSelected: c.ZagatSelected,
+	}
+	if len(v.errs) == 0 {
+		// The calls differ only by location and type, so the other parameters are reported once.
+		check := template
+		check.location = NewLocation(c.route[0].Lat, c.route[0].Lng)
+		if err := check.validate(); err != nil {
+			v.errs = append(v.errs, err.(ValidationErrors)...)
+		}
+	}
+	for _, t := range types {
+		v.featureType(t)
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	points := newRoute(c.route).sample(c.interval())
+	limit := c.MaxSearches
+	if limit == 0 {
+		limit = defaultCorridorMaxSearches
+	}
+	if limit > 0 && len(points)*len(types) > limit {
+		v.check("route", errTooManySearches)
+		return nil, v.err()
+	}
+
+	var calls []*NearbyCall
+	for _, point := range points {
+		for _, t := range types {
+			call := template
+			call.location = NewLocation(point.Lat, point.Lng)
+			call.Type = t
+			calls = append(calls, &call)
+		}
+	}
+	return calls, nil
+}
+
+// Do runs the searches along the route and merges the results. If any search fails the others are cancelled and the first error is returned. A search with no results does not cause an error.
+func (c *CorridorCall) Do() (*CorridorResponse, error) {
+	calls, err := c.calls()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(callContext(c.ctx))
+	defer cancel()
+
+	concurrency := c.MaxConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultCorridorConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+
+	lists := make([][]PlaceDetails, len(calls))
+	errs := make([]error, len(calls))
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		wg.Add(1)
+		go func(i int, call *NearbyCall) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			lists[i], errs[i] = collectPages(ctx, call, c.MaxPages)
+			if errs[i] != nil {
+				cancel()
+			}
+		}(i, call)
+	}
+	wg.Wait()
+
+	if err := firstError(errs); err != nil {
+		return nil, err
+	}
+
+	route := newRoute(c.route)
+	var results []CorridorResult
+	index := map[string]int{}
+	for i, list := range lists {
+		for _, place := range list {
+			if j, ok := index[place.PlaceID]; ok && place.PlaceID != "" {
+				results[j].addType(calls[i].Type)
+				continue
+			}
+			offset, distance := route.project(place.Geometry.Location)
+			if distance > c.buffer {
+				continue
+			}
+			index[place.PlaceID] = len(results)
+			result := CorridorResult{PlaceDetails: place, Offset: offset, Distance: distance}
+			result.addType(calls[i].Type)
+			results = append(results, result)
+		}
+	}
+
+	// Restore the order in which the types were requested, which concurrent searches along the route do not preserve.
+	order := map[FeatureType]int{}
+	for i, t := range c.types {
+		if _, ok := order[t]; !ok {
+			order[t] = i
+		}
+	}
+	for _, result := range results {
+		sort.SliceStable(result.MatchedTypes, func(i, j int) bool {
+			return order[result.MatchedTypes[i]] < order[result.MatchedTypes[j]]
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Offset != b.Offset {
+			return a.Offset < b.Offset
+		}
+		return a.Distance < b.Distance
+	})
+	return &CorridorResponse{Results: results}, nil
+}
+
+func (r *CorridorResult) addType(t FeatureType) {
+	if t == "" {
+		return
+	}
+	for _, have := range r.MatchedTypes {
+		if have == t {
+			return
+		}
+	}
+	r.MatchedTypes = append(r.MatchedTypes, t)
+}
+
+// route is a polyline with the distance along it to each of its points.
+type route struct {
+	points []LatLng
+	// offsets[i] is the distance in meters along the route from its start to points[i].
+	offsets []float64
+}
+
+func newRoute(points []LatLng) route {
+	offsets := make([]float64, len(points))
+	for i := 1; i < len(points); i++ {
+		offsets[i] = offsets[i-1] + Distance(points[i-1], points[i])
+	}
+	return route{points: points, offsets: offsets}
+}
+
+// length returns the length of the route in meters.
+func (r route) length() float64 {
+	if len(r.offsets) == 0 {
+		return 0
+	}
+	return r.offsets[len(r.offsets)-1]
+}
+
+// sample returns points along the route every interval meters, starting at its start and always including its end.
+func (r route) sample(interval float64) []LatLng {
+	if len(r.points) == 0 {
+		return nil
+	}
+	samples := []LatLng{r.points[0]}
+	last, next := 0.0, interval
+	for i := 0; i+1 < len(r.points); i++ {
+		for ; next <= r.offsets[i+1]; next += interval {
+			t := (next - r.offsets[i]) / (r.offsets[i+1] - r.offsets[i])
+			samples = append(samples, interpolate(r.points[i], r.points[i+1], t))
+			last = next
+		}
+	}
+	if r.length() > last {
+		samples = append(samples, r.points[len(r.points)-1])
+	}
+	return samples
+}
+
+// project returns the distance along the route to the point on it nearest p, and the distance from that point to p. Each segment is treated as a straight line on an equirectangular projection, which is accurate for segments of the length found in routes.
+func (r route) project(p LatLng) (offset, distance float64) {
+	if len(r.points) == 1 {
+		return 0, Distance(r.points[0], p)
+	}
+	distance = math.Inf(1)
+	for i := 0; i+1 < len(r.points); i++ {
+		a, b := r.points[i], r.points[i+1]
+		scale := math.Cos(radians(a.Lat))
+		bx, by := (b.Lng-a.Lng)*scale, b.Lat-a.Lat
+		px, py := (p.Lng-a.Lng)*scale, p.Lat-a.Lat
+
+		var t float64
+		if length := bx*bx + by*by; length > 0 {
+			t = math.Max(0, math.Min(1, (px*bx+py*by)/length))
+		}
+		nearest := interpolate(a, b, t)
+		if d := Distance(nearest, p); d < distance {
+			distance = d
+			offset = r.offsets[i] + Distance(a, nearest)
+		}
+	}
+	return offset, distance
+}
+
+// interpolate returns the point a fraction t of the way from a to b.
+func interpolate(a, b LatLng, t float64) LatLng {
+	return LatLng{
+		Lat: a.Lat + (b.Lat-a.Lat)*t,
+		Lng: a.Lng + (b.Lng-a.Lng)*t,
+	}
+}
@@ -0,0 +1,214 @@
+package places
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCorridorCallDo(t *testing.T) {
+	var mu sync.Mutex
+	var locations []string
+	radii := map[string]bool{}
+	handler := nearbyTypesHandler(map[string]string{
+		"gas_station": `{"status": "OK", "results": [` + place("near", 0.005, 0.05) + `,` + place("far", 0.02, 0.05) + `,` + place("end", 0, 0.09) + `]}`,
+		"restaurant":  `{"status": "OK", "results": [` + place("start", 0.001, -0.005) + `,` + place("near", 0.005, 0.05) + `]}`,
+	})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		if r.URL.Query().Get("type") == "gas_station" {
+			locations = append(locations, r.URL.Query().Get("location"))
+		}
+		radii[r.URL.Query().Get("radius")] = true
+		mu.Unlock()
+		handler(w, r)
+	}))
+	defer ts.Close()
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL))
+
+	// About 11.1 km east along the equator.
+	route := []LatLng{{0, 0}, {0, 0.05}, {0, 0.1}}
+	resp, err := service.Corridor(route, 1000, GasStation, Restaurant).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, r := range resp.Results {
+		got = append(got, fmt.Sprintf("%s:%v", r.PlaceID, r.MatchedTypes))
+	}
+	if want := "[start:[restaurant] near:[gas_station restaurant] end:[gas_station]]"; fmt.Sprint(got) != want {
+		t.Errorf("CorridorCall{}.Do() = %v, want %v", got, want)
+	}
+
+	near := resp.Results[1]
+	if math.Abs(near.Offset-5560) > 5 || math.Abs(near.Distance-556) > 2 {
+		t.Errorf("near: offset %.0f, distance %.0f, want about 5560 and 556", near.Offset, near.Distance)
+	}
+	if start := resp.Results[0]; start.Offset != 0 {
+		t.Errorf("start: offset %v, want 0 for a place before the start of the route", start.Offset)
+	}
+
+	// Searches every 2 km from the start, and one at the end.
+	if len(locations) != 7 {
+		t.Errorf("searched %d locations for each type, want 7: %v", len(locations), locations)
+	}
+	if want := map[string]bool{"1415": true}; fmt.Sprint(radii) != fmt.Sprint(want) {
+		t.Errorf("search radii = %v, want %v", radii, want)
+	}
+}
+
+func TestCorridorCallError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "OVER_QUERY_LIMIT"}`)
+	}))
+	defer ts.Close()
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL))
+
+	route := []LatLng{{0, 0}, {0, 0.1}}
+	if _, err := service.Corridor(route, 1000, Cafe).Do(); !IsOverQueryLimit(err) {
+		t.Errorf("CorridorCall{}.Do() = %v, want OVER_QUERY_LIMIT", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := service.Corridor(route, 1000, Cafe).Context(ctx).Do(); !errors.Is(err, context.Canceled) {
+		t.Errorf("CorridorCall{}.Do() with cancelled context = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestCorridorCallValidate(t *testing.T) {
+	route := []LatLng{{0, 0}, {0, 1}}
+	for _, test := range []struct {
+		Name   string
+		Call   *CorridorCall
+		Want   error
+		Fields string
+	}{
+		{
+			Name:   "single point",
+			Call:   dummyService.Corridor([]LatLng{{0, 0}}, 1000),
+			Want:   errShortRoute,
+			Fields: "route",
+		},
+		{
+			Name:   "point out of range",
+			Call:   dummyService.Corridor([]LatLng{{0, 0}, {91, 0}}, 1000),
+			Want:   errLatitudeOutOfRange,
+			Fields: "route",
+		},
+		{
+			Name:   "no buffer and bad type",
+			Call:   dummyService.Corridor(route, 0, Cafe, Locality),
+			Want:   errInvalidBuffer,
+			Fields: "buffer type",
+		},
+		{
+			Name:   "search radius too great",
+			Call:   &CorridorCall{service: dummyService, route: route, buffer: 30000, Interval: 100000},
+			Want:   errRadiusIsTooGreat,
+			Fields: "radius",
+		},
+		{
+			Name:   "negative interval",
+			Call:   &CorridorCall{service: dummyService, route: route, buffer: 1000, Interval: -1},
+			Want:   errNegativeSpacing,
+			Fields: "interval",
+		},
+		{
+			Name:   "too many searches",
+			Call:   dummyService.Corridor(route, 500, Cafe, Bar, Bakery),
+			Want:   errTooManySearches,
+			Fields: "route",
+		},
+		{
+			Name:   "more searches than MaxSearches",
+			Call:   &CorridorCall{service: dummyService, route: route, buffer: 5000, MaxSearches: 5},
+			Want:   errTooManySearches,
+			Fields: "route",
+		},
+	} {
+		_, err := test.Call.calls()
+		if !errors.Is(err, test.Want) {
+			t.Errorf("%s: CorridorCall{}.calls() = %v, want %v", test.Name, err, test.Want)
+			continue
+		}
+		var fields []string
+		for _, e := range err.(ValidationErrors) {
+			fields = append(fields, e.Field)
+		}
+		if got := strings.Join(fields, " "); got != test.Fields {
+			t.Errorf("%s: invalid fields = %q, want %q", test.Name, got, test.Fields)
+		}
+	}
+}
+
+func TestCorridorCallMaxSearches(t *testing.T) {
+	route := []LatLng{{0, 0}, {0, 1}}
+	call := dummyService.Corridor(route, 500, Cafe, Bar, Bakery)
+	call.MaxSearches = -1
+	calls, err := call.calls()
+	if err != nil {
+		t.Fatalf("CorridorCall{MaxSearches: -1}.calls() = %v", err)
+	}
+	if len(calls) <= defaultCorridorMaxSearches {
+		t.Errorf("CorridorCall{MaxSearches: -1}.calls() made %d searches, want more than %d", len(calls), defaultCorridorMaxSearches)
+	}
+
+	call.MaxSearches = len(calls)
+	if _, err := call.calls(); err != nil {
+		t.Errorf("CorridorCall{MaxSearches: %d}.calls() = %v, want nil", len(calls), err)
+	}
+}
+
+func TestRouteSample(t *testing.T) {
+	r := newRoute([]LatLng{{0, 0}, {0, 0}, {0, 0.03}, {0, 0.05}})
+	var got []string
+	for _, p := range r.sample(2000) {
+		got = append(got, fmt.Sprintf("%.4f", p.Lng))
+	}
+	if want := "[0.0000 0.0180 0.0360 0.0500]"; fmt.Sprint(got) != want {
+		t.Errorf("sample() = %v, want %v", got, want)
+	}
+
+	exact := newRoute([]LatLng{{0, 0}, {0, 0.1}})
+	if got := len(exact.sample(exact.length() / 2)); got != 3 {
+		t.Errorf("sample() at half the length = %d points, want 3", got)
+	}
+}
+
+func TestCorridorResultJSON(t *testing.T) {
+	in := CorridorResult{
+		PlaceDetails: PlaceDetails{PlaceID: "a", Name: "A", Rating: 4.5},
+		MatchedTypes: []FeatureType{Cafe, Bakery},
+		Offset:       1200.5,
+		Distance:     35.25,
+	}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out CorridorResult
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.PlaceID != in.PlaceID || out.Name != in.Name || out.Rating != in.Rating {
+		t.Errorf("place = %+v, want %+v", out.PlaceDetails, in.PlaceDetails)
+	}
+	if !reflect.DeepEqual(out.MatchedTypes, in.MatchedTypes) || out.Offset != in.Offset || out.Distance != in.Distance {
+		t.Errorf("got %v, %v, %v, want %v, %v, %v", out.MatchedTypes, out.Offset, out.Distance, in.MatchedTypes, in.Offset, in.Distance)
+	}
+	for _, key := range []string{"matched_types", "offset", "distance"} {
+		if out.present[key] {
+			t.Errorf("%s recorded as a place field", key)
+		}
+	}
+}
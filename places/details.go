@@ -1,9 +1,7 @@
 package places
 
 import (
-	"encoding/json"
-	"fmt"
-	"io/ioutil"
+	"context"
 	"net/http"
 	"net/url"
 )
@@ -18,53 +16,65 @@ func (p *Service) Details(placeid string) *DetailsCall {
 
 type DetailsCall struct {
 	service *Service
+	ctx     context.Context
 	placeID string
 
 	Extensions string
-	Language   string
+	// The language code, indicating in which language the results should be returned, if possible. Defaults to the service's language.
+	Language string
+	// The region code, specified as a ccTLD ("top-level domain") two-character value, used to format the result and bias it towards a region. Defaults to the service's region.
+	Region string
 }
 
-func (d *DetailsCall) query() string {
+// validate reports every invalid parameter of the call as ValidationErrors.
+func (d *DetailsCall) validate() error {
+	var v validator
+	v.check("placeid", validatePlaceID(d.placeID))
+	v.check("language", validateLanguage(d.service.languageOr(d.Language)))
+	return v.err()
+}
+
+func (d *DetailsCall) query() url.Values {
 	query := make(url.Values)
 
-	query.Add("key", d.service.key)
 	if d.Extensions != "" {
 		query.Add("extensions", d.Extensions)
 	}
-	if d.Language != "" {
-		query.Add("language", d.Language)
+	if language := d.service.languageOr(d.Language); language != "" {
+		query.Add("language", language)
+	}
+	if region := d.service.regionOr(d.Region); region != "" {
+		query.Add("region", region)
 	}
 	query.Add("placeid", d.placeID)
 
-	return query.Encode()
+	return query
 }
 
-func (d *DetailsCall) Do() (*DetailsResponse, error) {
-	searchURL := d.service.url + "/details/json?" + d.query()
+// Context sets the context used by Do. Cancelling it aborts the request.
+func (d *DetailsCall) Context(ctx context.Context) *DetailsCall {
+	d.ctx = ctx
+	return d
+}
 
-	resp, err := d.service.client.Get(searchURL)
-	if err != nil {
+// Request validates the call and returns the request Do would send, without credentials. Use Service.Authorize to add them before sending it.
+func (d *DetailsCall) Request(ctx context.Context) (*http.Request, error) {
+	if err := d.validate(); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	return d.service.request(callContext(ctx), "details", d.query())
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("bad resp %d: %s", resp.StatusCode, body)
+func (d *DetailsCall) Do() (*DetailsResponse, error) {
+	if err := d.validate(); err != nil {
+		return nil, err
 	}
 
 	data := &DetailsResponse{}
-	if err := json.NewDecoder(resp.Body).Decode(data); err != nil {
+	if err := d.service.get(callContext(d.ctx), "details", d.query(), data); err != nil {
 		return nil, err
 	}
 
-	if data.Status != "OK" {
-		return nil, &apiError{
-			Status:  data.Status,
-			Message: data.ErrorMessage,
-		}
-	}
-
 	return data, nil
 }
 
@@ -75,12 +85,31 @@ type DetailsResponse struct {
 	HTMLAttributions []string     `json:"html_attributions"`
 }
 
+func (r *DetailsResponse) apiStatus() (string, string) {
+	return r.Status, r.ErrorMessage
+}
+
+func (r *DetailsResponse) resultCount() int {
+	if r.Result.PlaceID == "" {
+		return 0
+	}
+	return 1
+}
+
+func (r *DetailsResponse) nextPageToken() string {
+	return ""
+}
+
 // DayTime is used in Period to specify opening and closing times.
 type DayTime struct {
 	// A number from 0–6, corresponding to the days of the week, starting on Sunday. For example, 2 means Tuesday.
 	Day int `json:"day"`
 	// May contain a time of day in 24-hour hhmm format. Values are in the range 0000–2359. The time will be reported in the place’s time zone.
 	Time string `json:"time"`
+	// The date of this day in the place's time zone, in YYYY-MM-DD format. Only set in CurrentOpeningHours and SecondaryOpeningHours.
+	Date string `json:"date,omitempty"`
+	// True if the period was cut off at the end of the seven days covered by CurrentOpeningHours and SecondaryOpeningHours.
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 // Period describes a time period when the place is open.
@@ -91,7 +120,7 @@ type Period struct {
 	Open DayTime `json:"open"`
 	// May contain a pair of day and time objects describing when the place closes.
 	Close DayTime `json:"close,omitempty"`
-	// An array of seven strings representing the formatted opening hours for each day of the week. If a language parameter was specified in the Place Details request, the Places Service will format and localize the opening hours appropriately for that language. The ordering of the elements in this array depends on the language parameter. Some languages start the week on Monday while others start on Sunday.
+	// Deprecated: The API returns weekday_text in the opening hours rather than in each period, so this is never set. Use OpeningHours.WeekdayText.
 	WeekdayText []string `json:"weekday_text"`
 }
 
@@ -122,8 +151,48 @@ type OpeningHours struct {
 	OpenNow bool `json:"open_now"`
 	// An array of opening periods covering seven days, starting from Sunday, in chronological order.
 	Periods []Period `json:"periods"`
+	// An array of seven strings representing the formatted opening hours for each day of the week. If a language parameter was specified in the Place Details request, the Places Service will format and localize the opening hours appropriately for that language. The ordering of the elements in this array depends on the language parameter. Some languages start the week on Monday while others start on Sunday.
+	WeekdayText []string `json:"weekday_text"`
+	// Days in the next seven days whose hours differ from the regular hours, e.g. public holidays. Only set in CurrentOpeningHours.
+	SpecialDays []SpecialDay `json:"special_days,omitempty"`
+	// The kind of service the hours apply to, e.g. DRIVE_THROUGH or DELIVERY. Only set in SecondaryOpeningHours.
+	Type string `json:"type,omitempty"`
+}
+
+// SpecialDay is a day in CurrentOpeningHours whose hours differ from the place's regular hours.
+type SpecialDay struct {
+	// The date in the place's time zone, in YYYY-MM-DD format.
+	Date string `json:"date"`
+	// True if the place has exceptional hours on this day.
+	ExceptionalHours bool `json:"exceptional_hours"`
+}
+
+// PlusCode is an encoded location reference, derived from latitude and longitude coordinates, that represents an area of 1/8000th of a degree by 1/8000th of a degree (about 14m x 14m at the equator) or smaller. Plus codes can be used as a replacement for street addresses in places where they do not exist.
+type PlusCode struct {
+	// A 4 character area code and 6 character or longer local code, e.g. "849VCWC8+R9".
+	GlobalCode string `json:"global_code"`
+	// A 6 character or longer local code with an explicit location, e.g. "CWC8+R9 Mountain View, CA, USA".
+	CompoundCode string `json:"compound_code"`
 }
 
+// EditorialSummary is a short description of a place written by Google.
+type EditorialSummary struct {
+	// The language of the overview, e.g. "en".
+	Language string `json:"language"`
+	// A medium-length textual summary of the place.
+	Overview string `json:"overview"`
+}
+
+// BusinessStatus indicates the operational status of a place, if it is a business.
+type BusinessStatus string
+
+// A place that is not a business has no BusinessStatus.
+const (
+	Operational       BusinessStatus = "OPERATIONAL"
+	ClosedTemporarily BusinessStatus = "CLOSED_TEMPORARILY"
+	ClosedPermanently BusinessStatus = "CLOSED_PERMANENTLY"
+)
+
 // Photo contains related photographic content to a place
 type Photo struct {
 	// A string used to identify the photo when you perform a Photo request.
@@ -201,7 +270,9 @@ type PlaceDetails struct {
 	// Contains information about when the place is open.
 	OpeningHours OpeningHours `json:"opening_hours"`
 	// A boolean flag indicating whether the place has permanently shut down (value true).
-	PermanentlyClosed bool `json:"permenantly_closed"`
+	//
+	// Deprecated: Use BusinessStatus.
+	PermanentlyClosed bool `json:"permanently_closed"`
 	// An array of photo objects, each containing a reference to an image. A Place Details request may return up to ten photos.
 	Photos []Photo `json:"photos"`
 	// A textual identifier that uniquely identifies a place.
@@ -221,7 +292,11 @@ type PlaceDetails struct {
 	// The URL of the official Google page for this place. This will be the establishment's Google+ page if the Google+ page exists, otherwise it will be the Google-owned page that contains the best available information about the place. Applications must link to or embed this page on any screen that shows detailed results about the place to the user.
 	URL string `json:"url"`
 	// The number of minutes this place’s current timezone is offset from UTC.
+	//
+	// Deprecated: Use UTCOffsetMinutes.
 	UTCOffset int `json:"utc_offset"`
+	// The number of minutes this place’s current timezone is offset from UTC. For example, for places in Sydney, Australia during daylight saving time this would be 660 (+11 hours from UTC).
+	UTCOffsetMinutes int `json:"utc_offset_minutes"`
 	// A simplified address for the place, including the street name, street number, and locality, but not the province/state, postal code, or country.
 	Vicinity string `json:"vicinity"`
 	// The authoritative website for this place, such as a business' homepage.
@@ -230,101 +305,51 @@ type PlaceDetails struct {
 	Aspects []AspectRating `json:"aspects"`
 	// Indicates that the place has been selected as a Zagat quality location. The Zagat label identifies places known for their consistently high quality or that have a special or unique character. (Only available to Google Places API for Work customers.)
 	ZagatSelected bool `json:"zagat_selected"`
-}
 
-// FeatureType is a feature type describing a place.
-type FeatureType string
+	// The total number of reviews, with or without text, for this place.
+	UserRatingsTotal int `json:"user_ratings_total"`
+	// The operational status of the place, if it is a business.
+	BusinessStatus BusinessStatus `json:"business_status"`
+	// An encoded location reference for the place.
+	PlusCode PlusCode `json:"plus_code"`
+	// A representation of the place's address in the adr microformat.
+	AdrAddress string `json:"adr_address"`
+	// The hours of operation for the next seven days, including any exceptional hours such as holidays.
+	CurrentOpeningHours OpeningHours `json:"current_opening_hours"`
+	// The hours of specific services, such as a drive-through or delivery, for the next seven days.
+	SecondaryOpeningHours []OpeningHours `json:"secondary_opening_hours"`
+	// A summary of the place written by Google.
+	EditorialSummary EditorialSummary `json:"editorial_summary"`
 
-// You can use the following values in the types filter for place searches and when adding a place.
-const (
-	Accounting            FeatureType = "accounting"
-	Airport               FeatureType = "airport"
-	AmusementPark         FeatureType = "amusement_park"
-	Aquarium              FeatureType = "aquarium"
-	ArtGallery            FeatureType = "art_gallery"
-	Atm                   FeatureType = "atm"
-	Bakery                FeatureType = "bakery"
-	Bank                  FeatureType = "bank"
-	Bar                   FeatureType = "bar"
-	BeautySalon           FeatureType = "beauty_salon"
-	BicycleStore          FeatureType = "bicycle_store"
-	BookStore             FeatureType = "book_store"
-	BowlingAlley          FeatureType = "bowling_alley"
-	BusStation            FeatureType = "bus_station"
-	Cafe                  FeatureType = "cafe"
-	Campground            FeatureType = "campground"
-	CarDealer             FeatureType = "car_dealer"
-	CarRental             FeatureType = "car_rental"
-	CarRepair             FeatureType = "car_repair"
-	CarWash               FeatureType = "car_wash"
-	Casino                FeatureType = "casino"
-	Cemetery              FeatureType = "cemetery"
-	Church                FeatureType = "church"
-	CityHall              FeatureType = "city_hall"
-	ClothingStore         FeatureType = "clothing_store"
-	ConvenienceStore      FeatureType = "convenience_store"
-	Courthouse            FeatureType = "courthouse"
-	Dentist               FeatureType = "dentist"
-	DepartmentStore       FeatureType = "department_store"
-	Doctor                FeatureType = "doctor"
-	Electrician           FeatureType = "electrician"
-	ElectronicsStore      FeatureType = "electronics_store"
-	Embassy               FeatureType = "embassy"
-	FireStation           FeatureType = "fire_station"
-	Florist               FeatureType = "florist"
-	FuneralHome           FeatureType = "funeral_home"
-	FurnitureStore        FeatureType = "furniture_store"
-	GasStation            FeatureType = "gas_station"
-	GroceryOrSupermarket  FeatureType = "grocery_or_supermarket"
-	Gym                   FeatureType = "gym"
-	HairCare              FeatureType = "hair_care"
-	HardwareStore         FeatureType = "hardware_store"
-	HinduTemple           FeatureType = "hindu_temple"
-	HomeGoodsStore        FeatureType = "home_goods_store"
-	Hospital              FeatureType = "hospital"
-	InsuranceAgency       FeatureType = "insurance_agency"
-	JewelryStore          FeatureType = "jewelry_store"
-	Laundry               FeatureType = "laundry"
-	Lawyer                FeatureType = "lawyer"
-	Library               FeatureType = "library"
-	LiquorStore           FeatureType = "liquor_store"
-	LocalGovernmentOffice FeatureType = "local_government_office"
-	Locksmith             FeatureType = "locksmith"
-	Lodging               FeatureType = "lodging"
-	MealDelivery          FeatureType = "meal_delivery"
-	MealTakeaway          FeatureType = "meal_takeaway"
-	Mosque                FeatureType = "mosque"
-	MovieRental           FeatureType = "movie_rental"
-	MovieTheater          FeatureType = "movie_theater"
-	MovingCompany         FeatureType = "moving_company"
-	Museum                FeatureType = "museum"
-	NightClub             FeatureType = "night_club"
-	Painter               FeatureType = "painter"
-	Park                  FeatureType = "park"
-	Parking               FeatureType = "parking"
-	PetStore              FeatureType = "pet_store"
-	Pharmacy              FeatureType = "pharmacy"
-	Physiotherapist       FeatureType = "physiotherapist"
-	Plumber               FeatureType = "plumber"
-	Police                FeatureType = "police"
-	PostOffice            FeatureType = "post_office"
-	RealEstateAgency      FeatureType = "real_estate_agency"
-	Restaurant            FeatureType = "restaurant"
-	RoofingContractor     FeatureType = "roofing_contractor"
-	RvPark                FeatureType = "rv_park"
-	School                FeatureType = "school"
-	ShoeStore             FeatureType = "shoe_store"
-	ShoppingMall          FeatureType = "shopping_mall"
-	Spa                   FeatureType = "spa"
-	Stadium               FeatureType = "stadium"
-	Storage               FeatureType = "storage"
-	Store                 FeatureType = "store"
-	SubwayStation         FeatureType = "subway_station"
-	Synagogue             FeatureType = "synagogue"
-	TaxiStand             FeatureType = "taxi_stand"
-	TrainStation          FeatureType = "train_station"
-	TravelAgency          FeatureType = "travel_agency"
-	University            FeatureType = "university"
-	VeterinaryCare        FeatureType = "veterinary_care"
-	Zoo                   FeatureType = "zoo"
-)
+	// The following attributes are nil when the API does not know whether they apply to the place.
+
+	// Whether the place has a wheelchair accessible entrance.
+	WheelchairAccessibleEntrance *bool `json:"wheelchair_accessible_entrance"`
+	// Whether the place supports curbside pickup.
+	CurbsidePickup *bool `json:"curbside_pickup"`
+	// Whether the place offers delivery.
+	Delivery *bool `json:"delivery"`
+	// Whether the place offers indoor or outdoor seating.
+	DineIn *bool `json:"dine_in"`
+	// Whether the place supports reservations.
+	Reservable *bool `json:"reservable"`
+	// Whether the place serves beer.
+	ServesBeer *bool `json:"serves_beer"`
+	// Whether the place serves breakfast.
+	ServesBreakfast *bool `json:"serves_breakfast"`
+	// Whether the place serves brunch.
+	ServesBrunch *bool `json:"serves_brunch"`
+	// Whether the place serves dinner.
+	ServesDinner *bool `json:"serves_dinner"`
+	// Whether the place serves lunch.
+	ServesLunch *bool `json:"serves_lunch"`
+	// Whether the place serves vegetarian food.
+	ServesVegetarianFood *bool `json:"serves_vegetarian_food"`
+	// Whether the place serves wine.
+	ServesWine *bool `json:"serves_wine"`
+	// Whether the place offers takeout.
+	Takeout *bool `json:"takeout"`
+
+	// present holds the JSON names of the fields in the response the place was decoded from, so fields sent with a zero value can be told apart from missing ones. It is nil for places built in code.
+	present map[string]bool
+}
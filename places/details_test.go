@@ -1,6 +1,7 @@
 package places
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -110,3 +111,108 @@ func readResponse(responseType string) string {
 
 	return string(response)
 }
+
+func TestDetailsCallValidate(t *testing.T) {
+	for _, test := range []struct {
+		Name string
+		Call DetailsCall
+		Want error
+	}{
+		{
+			Name: "no language",
+			Call: DetailsCall{placeID: "place"},
+			Want: nil,
+		},
+		{
+			Name: "supported language",
+			Call: DetailsCall{placeID: "place", Language: "en-GB"},
+			Want: nil,
+		},
+		{
+			Name: "regional variant",
+			Call: DetailsCall{placeID: "place", Language: "en-US"},
+			Want: nil,
+		},
+		{
+			Name: "script and region",
+			Call: DetailsCall{placeID: "place", Language: "zh-Hant-TW"},
+			Want: nil,
+		},
+		{
+			Name: "unsupported language",
+			Call: DetailsCall{placeID: "place", Language: "xx-US"},
+			Want: errUnsupportedLanguage,
+		},
+		{
+			Name: "malformed region",
+			Call: DetailsCall{placeID: "place", Language: "en-"},
+			Want: errUnsupportedLanguage,
+		},
+		{
+			Name: "missing place ID",
+			Call: DetailsCall{},
+			Want: errMissingPlaceID,
+		},
+		{
+			Name: "malformed place ID",
+			Call: DetailsCall{placeID: "ChIJN1t tDeuEmsRUsoyG83frY4"},
+			Want: errInvalidPlaceID,
+		},
+	} {
+		got := test.Call.validate()
+		if !errors.Is(got, test.Want) {
+			t.Errorf("DetailsCall{%v}.validate() = %#v, want %#v",
+				test.Name, got, test.Want)
+		}
+	}
+}
+
+func TestDetailsResponseFields(t *testing.T) {
+	yes, no := true, false
+
+	for _, test := range []struct {
+		Fixture string
+		Check   func(p PlaceDetails) string
+		Want    string
+	}{
+		{"ok", func(p PlaceDetails) string { return fmt.Sprint(p.UserRatingsTotal, p.UTCOffset) }, "99 660"},
+		{"ok", func(p PlaceDetails) string { return fmt.Sprint(len(p.OpeningHours.WeekdayText)) }, "7"},
+		{"ok", func(p PlaceDetails) string { return p.AdrAddress[:3] }, `5, `},
+		{"current_fields", func(p PlaceDetails) string { return fmt.Sprint(p.UserRatingsTotal) }, "4312"},
+		{"current_fields", func(p PlaceDetails) string { return string(p.BusinessStatus) }, "OPERATIONAL"},
+		{"current_fields", func(p PlaceDetails) string { return fmt.Sprintf("%+v", p.PlusCode) }, "{GlobalCode:4RRH45R7+G9 CompoundCode:45R7+G9 Sydney, New South Wales}"},
+		{"current_fields", func(p PlaceDetails) string { return p.AdrAddress[:33] }, `<span class="street-address">1 Ma`},
+		{"current_fields", func(p PlaceDetails) string { return fmt.Sprint(p.UTCOffsetMinutes) }, "660"},
+		{"current_fields", func(p PlaceDetails) string { return fmt.Sprintf("%+v", p.CurrentOpeningHours.Periods[1].Close) }, "{Day:1 Time:1500 Date:2026-10-26 Truncated:true}"},
+		{"current_fields", func(p PlaceDetails) string { return fmt.Sprintf("%+v", p.CurrentOpeningHours.SpecialDays) }, "[{Date:2026-10-26 ExceptionalHours:true}]"},
+		{"current_fields", func(p PlaceDetails) string { return p.CurrentOpeningHours.WeekdayText[6] }, "Sunday: 11:00 AM – 10:00 PM"},
+		{"current_fields", func(p PlaceDetails) string {
+			h := p.SecondaryOpeningHours[0]
+			return fmt.Sprintln(len(p.SecondaryOpeningHours), h.Type, h.Periods[0].Open.Time)
+		}, "1 DELIVERY 1700\n"},
+		{"current_fields", func(p PlaceDetails) string { return fmt.Sprintf("%+v", p.EditorialSummary) }, "{Language:en Overview:Harbourside oyster bar with Opera House views and a long list of local wines.}"},
+		{"current_fields", func(p PlaceDetails) string {
+			return fmt.Sprint(
+				*p.WheelchairAccessibleEntrance, *p.CurbsidePickup, *p.Delivery, *p.DineIn, *p.Reservable, *p.Takeout,
+			)
+		}, fmt.Sprint(yes, no, yes, yes, yes, no)},
+		{"current_fields", func(p PlaceDetails) string {
+			return fmt.Sprint(
+				*p.ServesBeer, *p.ServesBreakfast, *p.ServesBrunch, *p.ServesDinner, *p.ServesLunch, *p.ServesVegetarianFood, *p.ServesWine,
+			)
+		}, fmt.Sprint(yes, no, no, yes, yes, yes, yes)},
+		{"current_fields", func(p PlaceDetails) string { return fmt.Sprint(p.PermanentlyClosed) }, "false"},
+		{"permanently_closed", func(p PlaceDetails) string {
+			return fmt.Sprint(p.PermanentlyClosed, p.BusinessStatus == ClosedPermanently)
+		}, "true true"},
+		{"permanently_closed", func(p PlaceDetails) string { return fmt.Sprint(p.ServesBeer == nil, p.Takeout == nil) }, "true true"},
+	} {
+		var resp DetailsResponse
+		if err := json.Unmarshal([]byte(readResponse(test.Fixture)), &resp); err != nil {
+			t.Fatalf("%s: %v", test.Fixture, err)
+		}
+		if got := test.Check(resp.Result); got != test.Want {
+			t.Errorf("%s: decoded %q, want %q", test.Fixture, got, test.Want)
+		}
+	}
+}
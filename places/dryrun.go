@@ -0,0 +1,67 @@
+package places
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// dryRunResponse is decoded into the response of every call made in dry-run mode.
+var dryRunResponse = []byte(`{"status": "OK"}`)
+
+// DryRun records the requests a Service would send instead of sending them, e.g. to audit the cost of a job or to test code that builds calls. Every call made through a service in dry-run mode succeeds with an empty response.
+//
+// A DryRun is safe for concurrent use.
+type DryRun struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	counts   map[string]int
+}
+
+// NewDryRun returns an empty DryRun.
+func NewDryRun() *DryRun {
+	return &DryRun{counts: map[string]int{}}
+}
+
+// Requests returns the recorded requests in the order they were made. They do not carry credentials.
+func (d *DryRun) Requests() []*http.Request {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]*http.Request(nil), d.requests...)
+}
+
+// Counts returns the number of recorded requests for each endpoint, e.g. "nearbysearch" or "details".
+func (d *DryRun) Counts() map[string]int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	counts := make(map[string]int, len(d.counts))
+	for endpoint, n := range d.counts {
+		counts[endpoint] = n
+	}
+	return counts
+}
+
+// Reset forgets every recorded request.
+func (d *DryRun) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.requests = nil
+	d.counts = map[string]int{}
+}
+
+// record stores the request s would send to endpoint and fills data with an empty OK response.
+func (d *DryRun) record(ctx context.Context, s *Service, endpoint string, query url.Values, data apiResponse) error {
+	req, err := s.request(ctx, endpoint, query)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	d.requests = append(d.requests, req)
+	d.counts[endpoint]++
+	d.mu.Unlock()
+
+	return json.Unmarshal(dryRunResponse, data)
+}
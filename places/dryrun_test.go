@@ -0,0 +1,148 @@
+package places
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCallRequest(t *testing.T) {
+	service := NewService(http.DefaultClient, "secret-key", WithUserAgent("audit/1.0"), WithLanguage("fr"))
+
+	nearby := service.Nearby(48.8584, 2.2945)
+	nearby.Radius = 500
+	text := service.TextSearch("crêpes")
+	radar := service.RadarSearch(1000, 48.8584, 2.2945)
+	radar.Type = Cafe
+
+	for _, test := range []struct {
+		Name    string
+		Request func(context.Context) (*http.Request, error)
+		Want    string
+	}{
+		{"nearby", nearby.Request, "https://maps.googleapis.com/maps/api/place/nearbysearch/json?language=fr&location=48.858400%2C2.294500&radius=500"},
+		{"text search", text.Request, "https://maps.googleapis.com/maps/api/place/textsearch/json?language=fr&query=cr%C3%AApes"},
+		{"radar", radar.Request, "https://maps.googleapis.com/maps/api/place/radarsearch/json?location=48.858400%2C2.294500&radius=1000&type=cafe"},
+		{"details", service.Details("ChIJLU7jZClu5kcR4PcOOO6p3I0").Request, "https://maps.googleapis.com/maps/api/place/details/json?language=fr&placeid=ChIJLU7jZClu5kcR4PcOOO6p3I0"},
+	} {
+		req, err := test.Request(context.Background())
+		if err != nil {
+			t.Errorf("%s: Request() = %v", test.Name, err)
+			continue
+		}
+		if req.URL.String() != test.Want {
+			t.Errorf("%s: Request() URL = %s, want %s", test.Name, req.URL, test.Want)
+		}
+		if req.Header.Get("User-Agent") != "audit/1.0" {
+			t.Errorf("%s: Request() User-Agent = %q", test.Name, req.Header.Get("User-Agent"))
+		}
+	}
+
+	if _, err := service.Details("").Request(context.Background()); !IsValidation(err) {
+		t.Errorf("DetailsCall{}.Request() = %v, want a validation error", err)
+	}
+}
+
+func TestServiceAuthorize(t *testing.T) {
+	creds, err := NewClientCredentials("gme-example", "vNIXE0xscrmjlyV-12Nj_BvUPaw=", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := NewKeyPool(RoundRobin, "pool-a", "pool-b")
+
+	for _, test := range []struct {
+		Name    string
+		Service *Service
+		Want    string
+	}{
+		{"key", NewService(nil, "secret-key"), "key=secret-key&placeid=abc"},
+		{"key pool", NewService(nil, "unused", WithKeyPool(pool)), "key=pool-a&placeid=abc"},
+		{"client credentials", NewService(nil, "unused", WithClientCredentials(creds)),
+			"client=gme-example&placeid=abc&signature=" + creds.sign("/maps/api/place/details/json?client=gme-example&placeid=abc")},
+	} {
+		req, err := test.Service.Details("abc").Request(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := test.Service.Authorize(req); err != nil {
+			t.Errorf("%s: Authorize() = %v", test.Name, err)
+		}
+		if req.URL.RawQuery != test.Want {
+			t.Errorf("%s: Authorize() query = %s, want %s", test.Name, req.URL.RawQuery, test.Want)
+		}
+	}
+	for _, stats := range pool.Stats() {
+		if stats.Requests != 0 {
+			t.Errorf("KeyPool{}.Stats() after Authorize = %+v, want no requests counted", stats)
+		}
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer ts.Close()
+
+	dryRun := NewDryRun()
+	service := NewService(http.DefaultClient, "secret-key", WithBaseURL(ts.URL), WithDryRun(dryRun), WithCache(NewMemoryCache(0, 0)))
+
+	call := service.Nearby(-33.8670522, 151.1957362)
+	call.Radius = 500
+	pages := 0
+	err := call.Pages(context.Background(), func(resp *SearchResponse) error {
+		pages++
+		if resp.Status != "OK" || len(resp.Results) != 0 {
+			t.Errorf("dry run response = %+v, want empty OK response", resp)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pages != 1 {
+		t.Errorf("dry run returned %d pages, want 1", pages)
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, err := service.Details("ChIJLU7jZClu5kcR4PcOOO6p3I0").Do()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Status != "OK" || resp.Result.PlaceID != "" {
+			t.Errorf("dry run details = %+v, want empty OK response", resp)
+		}
+	}
+
+	if _, err := service.Details("").Do(); !errors.Is(err, errMissingPlaceID) {
+		t.Errorf("DetailsCall{}.Do() = %v, want %v", err, errMissingPlaceID)
+	}
+
+	if requests != 0 {
+		t.Errorf("dry run sent %d requests, want 0", requests)
+	}
+	if got := fmt.Sprint(dryRun.Counts()); got != "map[details:2 nearbysearch:1]" {
+		t.Errorf("DryRun.Counts() = %s", got)
+	}
+	recorded := dryRun.Requests()
+	if len(recorded) != 3 {
+		t.Fatalf("DryRun.Requests() has %d requests, want 3", len(recorded))
+	}
+	for _, req := range recorded {
+		if req.URL.Query().Get("key") != "" {
+			t.Errorf("recorded request %s carries the key", req.URL)
+		}
+	}
+	if want := ts.URL + "/details/json?placeid=ChIJLU7jZClu5kcR4PcOOO6p3I0"; recorded[1].URL.String() != want {
+		t.Errorf("recorded request = %s, want %s", recorded[1].URL, want)
+	}
+
+	dryRun.Reset()
+	if len(dryRun.Requests()) != 0 || len(dryRun.Counts()) != 0 {
+		t.Error("DryRun.Reset() kept recorded requests")
+	}
+}
@@ -0,0 +1,148 @@
+package places
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultEnrichWorkers is the number of Details requests an Enricher sends at once unless Workers is set.
+const defaultEnrichWorkers = 4
+
+// Enricher fetches the details of many places concurrently, e.g. to complete the partial results of a search. Requests go through the service as usual, so its limiter, cache, retry policy and circuit breaker all apply; Workers only bounds how many are in flight at once.
+//
+//	resp, _ := service.Nearby(lat, lng).Do()
+//	results, err := service.Enricher().Enrich(ctx, resp.Results)
+type Enricher struct {
+	service *Service
+
+	// Workers limits how many Details requests are sent at once. Zero means 4.
+	Workers int
+	// The extensions to request, as in DetailsCall.
+	Extensions string
+	// The language code, indicating in which language the results should be returned, if possible. Defaults to the service's language.
+	Language string
+	// The region code used to format the results and bias them towards a region. Defaults to the service's region.
+	Region string
+}
+
+// Enricher returns an Enricher that fetches details with the service.
+func (p *Service) Enricher() *Enricher {
+	return &Enricher{service: p}
+}
+
+// EnrichResult is the outcome of fetching the details of one place.
+type EnrichResult struct {
+	// Index is the position of the place in the input.
+	Index int
+	// Place is the input place merged with the Details result by PlaceDetails.Merge. If the request failed it is the input place unchanged.
+	Place PlaceDetails
+	// Err is the error from the Details request, if any.
+	Err error
+}
+
+// Enrich fetches the details of every place and returns one result for each, in the same order. A failed request is reported in its result and does not stop the others; if ctx ends, the places not yet fetched fail with its error. The returned error is only set if the Enricher's own parameters are invalid, in which case nothing is sent.
+func (e *Enricher) Enrich(ctx context.Context, places []PlaceDetails) ([]EnrichResult, error) {
+	if err := e.validate(); err != nil {
+		return nil, err
+	}
+
+	results := make([]EnrichResult, len(places))
+	done := make([]bool, len(places))
+	in := make(chan PlaceDetails)
+	go func() {
+		defer close(in)
+		for _, place := range places {
+			select {
+			case in <- place:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	for result := range e.Stream(ctx, in) {
+		results[result.Index] = result
+		done[result.Index] = true
+	}
+
+	for i := range results {
+		if !done[i] {
+			results[i] = EnrichResult{Index: i, Place: places[i], Err: ctx.Err()}
+		}
+	}
+	return results, nil
+}
+
+// EnrichIDs fetches the details of the places with the given IDs, as Enrich does.
+func (e *Enricher) EnrichIDs(ctx context.Context, ids []string) ([]EnrichResult, error) {
+	places := make([]PlaceDetails, len(ids))
+	for i, id := range ids {
+		places[i].PlaceID = id
+	}
+	return e.Enrich(ctx, places)
+}
+
+// Stream fetches the details of each place received from in, sending a result for each to the returned channel as soon as it is ready, so results may arrive out of order; Index counts the places in the order they were received. The channel is closed once in is closed and every result has been sent, or once ctx ends and the requests in flight have finished. The caller must read the channel until it is closed.
+func (e *Enricher) Stream(ctx context.Context, in <-chan PlaceDetails) <-chan EnrichResult {
+	jobs := make(chan EnrichResult)
+	go func() {
+		defer close(jobs)
+		for i := 0; ; i++ {
+			select {
+			case place, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case jobs <- EnrichResult{Index: i, Place: place}:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	workers := e.Workers
+	if workers <= 0 {
+		workers = defaultEnrichWorkers
+	}
+	out := make(chan EnrichResult)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				out <- e.enrich(ctx, job)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// validate reports the invalid parameters shared by every Details request as ValidationErrors.
+func (e *Enricher) validate() error {
+	var v validator
+	v.check("language", validateLanguage(e.service.languageOr(e.Language)))
+	return v.err()
+}
+
+// enrich fetches the details of the place in job and fills them in.
+func (e *Enricher) enrich(ctx context.Context, job EnrichResult) EnrichResult {
+	call := e.service.Details(job.Place.PlaceID)
+	call.Extensions = e.Extensions
+	call.Language = e.Language
+	call.Region = e.Region
+	resp, err := call.Context(ctx).Do()
+	if err != nil {
+		job.Err = err
+		return job
+	}
+	job.Place.Merge(&resp.Result)
+	return job
+}
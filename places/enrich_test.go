@@ -0,0 +1,149 @@
+package places
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// detailsByID serves Details responses for the places in results, keyed by place ID, and NOT_FOUND for any other ID.
+func detailsByID(results map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		result, ok := results[r.URL.Query().Get("placeid")]
+		if !ok {
+			fmt.Fprint(w, `{"status": "NOT_FOUND"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status": "OK", "result": %s}`, result)
+	}
+}
+
+func TestEnricherEnrich(t *testing.T) {
+	ts := httptest.NewServer(detailsByID(map[string]string{
+		"cafe":   `{"place_id": "cafe", "name": "Cafe", "website": "https://cafe.example", "rating": 4.5}`,
+		"bakery": `{"place_id": "bakery", "name": "Bakery", "formatted_phone_number": "555 0100"}`,
+	}))
+	defer ts.Close()
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL))
+
+	partial := []PlaceDetails{
+		{PlaceID: "cafe", Name: "Cafe", Vicinity: "1 Main St", Rating: 4.4},
+		{PlaceID: "closed", Name: "Closed"},
+		{PlaceID: "bakery", Name: "Bakery", Vicinity: "2 Main St"},
+		{PlaceID: "not a place id"},
+	}
+	results, err := service.Enricher().Enrich(context.Background(), partial)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(partial) {
+		t.Fatalf("Enrich() returned %d results, want %d", len(results), len(partial))
+	}
+	for i, r := range results {
+		if r.Index != i {
+			t.Errorf("results[%d].Index = %d", i, r.Index)
+		}
+	}
+
+	if r := results[0]; r.Err != nil || r.Place.Website != "https://cafe.example" || r.Place.Rating != 4.5 || r.Place.Vicinity != "1 Main St" {
+		t.Errorf("cafe = %+v, %v, want details filled in over the search result", r.Place, r.Err)
+	}
+	if r := results[1]; !IsNotFound(r.Err) || r.Place.Name != "Closed" {
+		t.Errorf("closed = %+v, %v, want the input place and NOT_FOUND", r.Place, r.Err)
+	}
+	if r := results[2]; r.Err != nil || r.Place.FormattedPhoneNumber != "555 0100" || r.Place.Vicinity != "2 Main St" {
+		t.Errorf("bakery = %+v, %v", r.Place, r.Err)
+	}
+	if r := results[3]; !errors.Is(r.Err, errInvalidPlaceID) {
+		t.Errorf("invalid ID error = %v, want %v", r.Err, errInvalidPlaceID)
+	}
+}
+
+func TestEnricherWorkers(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, most := 0, 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > most {
+			most = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		fmt.Fprintf(w, `{"status": "OK", "result": {"place_id": %q}}`, r.URL.Query().Get("placeid"))
+	}))
+	defer ts.Close()
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL))
+
+	ids := make([]string, 12)
+	for i := range ids {
+		ids[i] = fmt.Sprint("place", i)
+	}
+	e := service.Enricher()
+	e.Workers = 3
+	results, err := e.EnrichIDs(context.Background(), ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range results {
+		if r.Err != nil || r.Place.PlaceID != ids[i] {
+			t.Errorf("results[%d] = %q, %v, want %q", i, r.Place.PlaceID, r.Err, ids[i])
+		}
+	}
+	if most > 3 {
+		t.Errorf("%d requests in flight at once, want at most 3", most)
+	}
+}
+
+func TestEnricherStream(t *testing.T) {
+	ts := httptest.NewServer(detailsByID(map[string]string{
+		"a": `{"place_id": "a", "name": "A"}`,
+		"b": `{"place_id": "b", "name": "B"}`,
+	}))
+	defer ts.Close()
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL))
+
+	in := make(chan PlaceDetails)
+	go func() {
+		in <- PlaceDetails{PlaceID: "a"}
+		in <- PlaceDetails{PlaceID: "b"}
+		close(in)
+	}()
+	names := map[int]string{}
+	for r := range service.Enricher().Stream(context.Background(), in) {
+		names[r.Index] = r.Place.Name
+	}
+	if got := fmt.Sprint(names); got != "map[0:A 1:B]" {
+		t.Errorf("Stream() names = %s, want map[0:A 1:B]", got)
+	}
+}
+
+func TestEnricherCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := dummyService.Enricher().EnrichIDs(ctx, []string{"a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range results {
+		if !errors.Is(r.Err, context.Canceled) || r.Place.PlaceID == "" {
+			t.Errorf("results[%d] = %+v, want the input place and %v", i, r, context.Canceled)
+		}
+	}
+}
+
+func TestEnricherValidate(t *testing.T) {
+	e := dummyService.Enricher()
+	e.Language = "xx-invalid"
+	if _, err := e.EnrichIDs(context.Background(), []string{"a"}); !IsValidation(err) {
+		t.Errorf("Enrich() with invalid language = %v, want a validation error", err)
+	}
+}
@@ -0,0 +1,408 @@
+package places
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+var (
+	errUnknownType      = errors.New("the type is not a supported place type")
+	errResponseOnlyType = errors.New("the type is only returned in results and cannot be used as a search filter")
+)
+
+// FeatureType is a feature type describing a place.
+type FeatureType string
+
+// You can use the following values in the types filter for place searches and when adding a place.
+const (
+	Accounting            FeatureType = "accounting"
+	Airport               FeatureType = "airport"
+	AmusementPark         FeatureType = "amusement_park"
+	Aquarium              FeatureType = "aquarium"
+	ArtGallery            FeatureType = "art_gallery"
+	Atm                   FeatureType = "atm"
+	Bakery                FeatureType = "bakery"
+	Bank                  FeatureType = "bank"
+	Bar                   FeatureType = "bar"
+	BeautySalon           FeatureType = "beauty_salon"
+	BicycleStore          FeatureType = "bicycle_store"
+	BookStore             FeatureType = "book_store"
+	BowlingAlley          FeatureType = "bowling_alley"
+	BusStation            FeatureType = "bus_station"
+	Cafe                  FeatureType = "cafe"
+	Campground            FeatureType = "campground"
+	CarDealer             FeatureType = "car_dealer"
+	CarRental             FeatureType = "car_rental"
+	CarRepair             FeatureType = "car_repair"
+	CarWash               FeatureType = "car_wash"
+	Casino                FeatureType = "casino"
+	Cemetery              FeatureType = "cemetery"
+	Church                FeatureType = "church"
+	CityHall              FeatureType = "city_hall"
+	ClothingStore         FeatureType = "clothing_store"
+	ConvenienceStore      FeatureType = "convenience_store"
+	Courthouse            FeatureType = "courthouse"
+	Dentist               FeatureType = "dentist"
+	DepartmentStore       FeatureType = "department_store"
+	Doctor                FeatureType = "doctor"
+	Drugstore             FeatureType = "drugstore"
+	Electrician           FeatureType = "electrician"
+	ElectronicsStore      FeatureType = "electronics_store"
+	Embassy               FeatureType = "embassy"
+	FireStation           FeatureType = "fire_station"
+	Florist               FeatureType = "florist"
+	FuneralHome           FeatureType = "funeral_home"
+	FurnitureStore        FeatureType = "furniture_store"
+	GasStation            FeatureType = "gas_station"
+	GroceryOrSupermarket  FeatureType = "grocery_or_supermarket"
+	Gym                   FeatureType = "gym"
+	HairCare              FeatureType = "hair_care"
+	HardwareStore         FeatureType = "hardware_store"
+	HinduTemple           FeatureType = "hindu_temple"
+	HomeGoodsStore        FeatureType = "home_goods_store"
+	Hospital              FeatureType = "hospital"
+	InsuranceAgency       FeatureType = "insurance_agency"
+	JewelryStore          FeatureType = "jewelry_store"
+	Laundry               FeatureType = "laundry"
+	Lawyer                FeatureType = "lawyer"
+	Library               FeatureType = "library"
+	LightRailStation      FeatureType = "light_rail_station"
+	LiquorStore           FeatureType = "liquor_store"
+	LocalGovernmentOffice FeatureType = "local_government_office"
+	Locksmith             FeatureType = "locksmith"
+	Lodging               FeatureType = "lodging"
+	MealDelivery          FeatureType = "meal_delivery"
+	MealTakeaway          FeatureType = "meal_takeaway"
+	Mosque                FeatureType = "mosque"
+	MovieRental           FeatureType = "movie_rental"
+	MovieTheater          FeatureType = "movie_theater"
+	MovingCompany         FeatureType = "moving_company"
+	Museum                FeatureType = "museum"
+	NightClub             FeatureType = "night_club"
+	Painter               FeatureType = "painter"
+	Park                  FeatureType = "park"
+	Parking               FeatureType = "parking"
+	PetStore              FeatureType = "pet_store"
+	Pharmacy              FeatureType = "pharmacy"
+	Physiotherapist       FeatureType = "physiotherapist"
+	Plumber               FeatureType = "plumber"
+	Police                FeatureType = "police"
+	PostOffice            FeatureType = "post_office"
+	PrimarySchool         FeatureType = "primary_school"
+	RealEstateAgency      FeatureType = "real_estate_agency"
+	Restaurant            FeatureType = "restaurant"
+	RoofingContractor     FeatureType = "roofing_contractor"
+	RvPark                FeatureType = "rv_park"
+	School                FeatureType = "school"
+	SecondarySchool       FeatureType = "secondary_school"
+	ShoeStore             FeatureType = "shoe_store"
+	ShoppingMall          FeatureType = "shopping_mall"
+	Spa                   FeatureType = "spa"
+	Stadium               FeatureType = "stadium"
+	Storage               FeatureType = "storage"
+	Store                 FeatureType = "store"
+	SubwayStation         FeatureType = "subway_station"
+	Supermarket           FeatureType = "supermarket"
+	Synagogue             FeatureType = "synagogue"
+	TaxiStand             FeatureType = "taxi_stand"
+	TouristAttraction     FeatureType = "tourist_attraction"
+	TrainStation          FeatureType = "train_station"
+	TransitStation        FeatureType = "transit_station"
+	TravelAgency          FeatureType = "travel_agency"
+	University            FeatureType = "university"
+	VeterinaryCare        FeatureType = "veterinary_care"
+	Zoo                   FeatureType = "zoo"
+)
+
+// The following values are only returned in results, in a place's types and in the types of its address components. They cannot be used as the type filter of a search.
+const (
+	AdministrativeAreaLevel1 FeatureType = "administrative_area_level_1"
+	AdministrativeAreaLevel2 FeatureType = "administrative_area_level_2"
+	AdministrativeAreaLevel3 FeatureType = "administrative_area_level_3"
+	AdministrativeAreaLevel4 FeatureType = "administrative_area_level_4"
+	AdministrativeAreaLevel5 FeatureType = "administrative_area_level_5"
+	AdministrativeAreaLevel6 FeatureType = "administrative_area_level_6"
+	AdministrativeAreaLevel7 FeatureType = "administrative_area_level_7"
+	Archipelago              FeatureType = "archipelago"
+	ColloquialArea           FeatureType = "colloquial_area"
+	Continent                FeatureType = "continent"
+	Country                  FeatureType = "country"
+	Establishment            FeatureType = "establishment"
+	Finance                  FeatureType = "finance"
+	Floor                    FeatureType = "floor"
+	Food                     FeatureType = "food"
+	GeneralContractor        FeatureType = "general_contractor"
+	Geocode                  FeatureType = "geocode"
+	Health                   FeatureType = "health"
+	Intersection             FeatureType = "intersection"
+	Landmark                 FeatureType = "landmark"
+	Locality                 FeatureType = "locality"
+	NaturalFeature           FeatureType = "natural_feature"
+	Neighborhood             FeatureType = "neighborhood"
+	PlaceOfWorship           FeatureType = "place_of_worship"
+	PlusCodeType             FeatureType = "plus_code" // Named so as not to clash with the PlusCode type.
+	Political                FeatureType = "political"
+	PointOfInterest          FeatureType = "point_of_interest"
+	PostBox                  FeatureType = "post_box"
+	PostalCode               FeatureType = "postal_code"
+	PostalCodePrefix         FeatureType = "postal_code_prefix"
+	PostalCodeSuffix         FeatureType = "postal_code_suffix"
+	PostalTown               FeatureType = "postal_town"
+	Premise                  FeatureType = "premise"
+	Room                     FeatureType = "room"
+	Route                    FeatureType = "route"
+	StreetAddress            FeatureType = "street_address"
+	StreetNumber             FeatureType = "street_number"
+	Sublocality              FeatureType = "sublocality"
+	SublocalityLevel1        FeatureType = "sublocality_level_1"
+	SublocalityLevel2        FeatureType = "sublocality_level_2"
+	SublocalityLevel3        FeatureType = "sublocality_level_3"
+	SublocalityLevel4        FeatureType = "sublocality_level_4"
+	SublocalityLevel5        FeatureType = "sublocality_level_5"
+	Subpremise               FeatureType = "subpremise"
+	TownSquare               FeatureType = "town_square"
+)
+
+// TypeClass describes where a FeatureType may be used. A type may belong to more than one class.
+type TypeClass int
+
+const (
+	// Searchable types may be used as the type filter of a place search. They are listed in Table 1 of the Places API documentation.
+	Searchable TypeClass = 1 << iota
+	// ResponseOnly types are only ever returned in results. They are listed in Table 2 of the Places API documentation.
+	ResponseOnly
+	// AddressComponentType types describe the parts of an address, such as a route or locality.
+	AddressComponentType
+)
+
+func (c TypeClass) String() string {
+	var names []string
+	for _, class := range []struct {
+		class TypeClass
+		name  string
+	}{
+		{Searchable, "searchable"},
+		{ResponseOnly, "response-only"},
+		{AddressComponentType, "address-component"},
+	} {
+		if c&class.class != 0 {
+			names = append(names, class.name)
+		}
+	}
+	if len(names) == 0 {
+		return "unknown"
+	}
+	return strings.Join(names, "|")
+}
+
+// Category groups related feature types, e.g. restaurants, cafes and bars are all food.
+type Category string
+
+const (
+	CategoryAutomotive    Category = "automotive"
+	CategoryEducation     Category = "education"
+	CategoryEntertainment Category = "entertainment"
+	CategoryFinance       Category = "finance"
+	CategoryFood          Category = "food"
+	CategoryGovernment    Category = "government"
+	CategoryHealth        Category = "health"
+	CategoryLodging       Category = "lodging"
+	CategoryServices      Category = "services"
+	CategoryShopping      Category = "shopping"
+	CategoryTransit       Category = "transit"
+	CategoryWorship       Category = "worship"
+)
+
+type typeInfo struct {
+	class    TypeClass
+	category Category
+}
+
+// featureTypes classifies every known feature type.
+var featureTypes = map[FeatureType]typeInfo{
+	Accounting:               {Searchable, CategoryFinance},
+	Airport:                  {Searchable, CategoryTransit},
+	AmusementPark:            {Searchable, CategoryEntertainment},
+	Aquarium:                 {Searchable, CategoryEntertainment},
+	ArtGallery:               {Searchable, CategoryEntertainment},
+	Atm:                      {Searchable, CategoryFinance},
+	Bakery:                   {Searchable, CategoryFood},
+	Bank:                     {Searchable, CategoryFinance},
+	Bar:                      {Searchable, CategoryFood},
+	BeautySalon:              {Searchable, CategoryServices},
+	BicycleStore:             {Searchable, CategoryShopping},
+	BookStore:                {Searchable, CategoryShopping},
+	BowlingAlley:             {Searchable, CategoryEntertainment},
+	BusStation:               {Searchable, CategoryTransit},
+	Cafe:                     {Searchable, CategoryFood},
+	Campground:               {Searchable, CategoryLodging},
+	CarDealer:                {Searchable, CategoryAutomotive},
+	CarRental:                {Searchable, CategoryAutomotive},
+	CarRepair:                {Searchable, CategoryAutomotive},
+	CarWash:                  {Searchable, CategoryAutomotive},
+	Casino:                   {Searchable, CategoryEntertainment},
+	Cemetery:                 {Searchable, CategoryWorship},
+	Church:                   {Searchable, CategoryWorship},
+	CityHall:                 {Searchable, CategoryGovernment},
+	ClothingStore:            {Searchable, CategoryShopping},
+	ConvenienceStore:         {Searchable, CategoryShopping},
+	Courthouse:               {Searchable, CategoryGovernment},
+	Dentist:                  {Searchable, CategoryHealth},
+	DepartmentStore:          {Searchable, CategoryShopping},
+	Doctor:                   {Searchable, CategoryHealth},
+	Drugstore:                {Searchable, CategoryHealth},
+	Electrician:              {Searchable, CategoryServices},
+	ElectronicsStore:         {Searchable, CategoryShopping},
+	Embassy:                  {Searchable, CategoryGovernment},
+	FireStation:              {Searchable, CategoryGovernment},
+	Florist:                  {Searchable, CategoryShopping},
+	FuneralHome:              {Searchable, CategoryServices},
+	FurnitureStore:           {Searchable, CategoryShopping},
+	GasStation:               {Searchable, CategoryAutomotive},
+	GroceryOrSupermarket:     {Searchable, CategoryFood},
+	Gym:                      {Searchable, CategoryHealth},
+	HairCare:                 {Searchable, CategoryServices},
+	HardwareStore:            {Searchable, CategoryShopping},
+	HinduTemple:              {Searchable, CategoryWorship},
+	HomeGoodsStore:           {Searchable, CategoryShopping},
+	Hospital:                 {Searchable, CategoryHealth},
+	InsuranceAgency:          {Searchable, CategoryFinance},
+	JewelryStore:             {Searchable, CategoryShopping},
+	Laundry:                  {Searchable, CategoryServices},
+	Lawyer:                   {Searchable, CategoryServices},
+	Library:                  {Searchable, CategoryEducation},
+	LightRailStation:         {Searchable, CategoryTransit},
+	LiquorStore:              {Searchable, CategoryShopping},
+	LocalGovernmentOffice:    {Searchable, CategoryGovernment},
+	Locksmith:                {Searchable, CategoryServices},
+	Lodging:                  {Searchable, CategoryLodging},
+	MealDelivery:             {Searchable, CategoryFood},
+	MealTakeaway:             {Searchable, CategoryFood},
+	Mosque:                   {Searchable, CategoryWorship},
+	MovieRental:              {Searchable, CategoryEntertainment},
+	MovieTheater:             {Searchable, CategoryEntertainment},
+	MovingCompany:            {Searchable, CategoryServices},
+	Museum:                   {Searchable, CategoryEntertainment},
+	NightClub:                {Searchable, CategoryEntertainment},
+	Painter:                  {Searchable, CategoryServices},
+	Park:                     {Searchable, CategoryEntertainment},
+	Parking:                  {Searchable, CategoryAutomotive},
+	PetStore:                 {Searchable, CategoryShopping},
+	Pharmacy:                 {Searchable, CategoryHealth},
+	Physiotherapist:          {Searchable, CategoryHealth},
+	Plumber:                  {Searchable, CategoryServices},
+	Police:                   {Searchable, CategoryGovernment},
+	PostOffice:               {Searchable, CategoryGovernment},
+	PrimarySchool:            {Searchable, CategoryEducation},
+	RealEstateAgency:         {Searchable, CategoryServices},
+	Restaurant:               {Searchable, CategoryFood},
+	RoofingContractor:        {Searchable, CategoryServices},
+	RvPark:                   {Searchable, CategoryLodging},
+	School:                   {Searchable, CategoryEducation},
+	SecondarySchool:          {Searchable, CategoryEducation},
+	ShoeStore:                {Searchable, CategoryShopping},
+	ShoppingMall:             {Searchable, CategoryShopping},
+	Spa:                      {Searchable, CategoryServices},
+	Stadium:                  {Searchable, CategoryEntertainment},
+	Storage:                  {Searchable, CategoryServices},
+	Store:                    {Searchable, CategoryShopping},
+	SubwayStation:            {Searchable, CategoryTransit},
+	Supermarket:              {Searchable, CategoryFood},
+	Synagogue:                {Searchable, CategoryWorship},
+	TaxiStand:                {Searchable, CategoryTransit},
+	TouristAttraction:        {Searchable, CategoryEntertainment},
+	TrainStation:             {Searchable, CategoryTransit},
+	TransitStation:           {Searchable, CategoryTransit},
+	TravelAgency:             {Searchable, CategoryServices},
+	University:               {Searchable, CategoryEducation},
+	VeterinaryCare:           {Searchable, CategoryHealth},
+	Zoo:                      {Searchable, CategoryEntertainment},
+	AdministrativeAreaLevel1: {class: ResponseOnly | AddressComponentType},
+	AdministrativeAreaLevel2: {class: ResponseOnly | AddressComponentType},
+	AdministrativeAreaLevel3: {class: ResponseOnly | AddressComponentType},
+	AdministrativeAreaLevel4: {class: ResponseOnly | AddressComponentType},
+	AdministrativeAreaLevel5: {class: ResponseOnly | AddressComponentType},
+	AdministrativeAreaLevel6: {class: ResponseOnly | AddressComponentType},
+	AdministrativeAreaLevel7: {class: ResponseOnly | AddressComponentType},
+	Archipelago:              {class: ResponseOnly | AddressComponentType},
+	ColloquialArea:           {class: ResponseOnly | AddressComponentType},
+	Continent:                {class: ResponseOnly | AddressComponentType},
+	Country:                  {class: ResponseOnly | AddressComponentType},
+	Establishment:            {class: ResponseOnly},
+	Finance:                  {ResponseOnly, CategoryFinance},
+	Floor:                    {class: ResponseOnly | AddressComponentType},
+	Food:                     {ResponseOnly, CategoryFood},
+	GeneralContractor:        {ResponseOnly, CategoryServices},
+	Geocode:                  {class: ResponseOnly},
+	Health:                   {ResponseOnly, CategoryHealth},
+	Intersection:             {class: ResponseOnly | AddressComponentType},
+	Landmark:                 {class: ResponseOnly | AddressComponentType},
+	Locality:                 {class: ResponseOnly | AddressComponentType},
+	NaturalFeature:           {class: ResponseOnly | AddressComponentType},
+	Neighborhood:             {class: ResponseOnly | AddressComponentType},
+	PlaceOfWorship:           {ResponseOnly, CategoryWorship},
+	PlusCodeType:             {class: ResponseOnly | AddressComponentType},
+	Political:                {class: ResponseOnly | AddressComponentType},
+	PointOfInterest:          {class: ResponseOnly},
+	PostBox:                  {class: ResponseOnly | AddressComponentType},
+	PostalCode:               {class: ResponseOnly | AddressComponentType},
+	PostalCodePrefix:         {class: ResponseOnly | AddressComponentType},
+	PostalCodeSuffix:         {class: ResponseOnly | AddressComponentType},
+	PostalTown:               {class: ResponseOnly | AddressComponentType},
+	Premise:                  {class: ResponseOnly | AddressComponentType},
+	Room:                     {class: ResponseOnly | AddressComponentType},
+	Route:                    {class: ResponseOnly | AddressComponentType},
+	StreetAddress:            {class: ResponseOnly},
+	StreetNumber:             {class: ResponseOnly | AddressComponentType},
+	Sublocality:              {class: ResponseOnly | AddressComponentType},
+	SublocalityLevel1:        {class: ResponseOnly | AddressComponentType},
+	SublocalityLevel2:        {class: ResponseOnly | AddressComponentType},
+	SublocalityLevel3:        {class: ResponseOnly | AddressComponentType},
+	SublocalityLevel4:        {class: ResponseOnly | AddressComponentType},
+	SublocalityLevel5:        {class: ResponseOnly | AddressComponentType},
+	Subpremise:               {class: ResponseOnly | AddressComponentType},
+	TownSquare:               {class: ResponseOnly | AddressComponentType},
+}
+
+// ParseFeatureType returns the FeatureType named by s, ignoring case and surrounding space. It returns an error if the type is unknown.
+func ParseFeatureType(s string) (FeatureType, error) {
+	t := FeatureType(strings.ToLower(strings.TrimSpace(s)))
+	if !t.Valid() {
+		return "", errUnknownType
+	}
+	return t, nil
+}
+
+// Valid returns true if t is a known feature type.
+func (t FeatureType) Valid() bool {
+	_, ok := featureTypes[t]
+	return ok
+}
+
+// Class returns where t may be used, or 0 if t is unknown.
+func (t FeatureType) Class() TypeClass {
+	return featureTypes[t].class
+}
+
+// Searchable returns true if t may be used as the type filter of a place search.
+func (t FeatureType) Searchable() bool {
+	return t.Class()&Searchable != 0
+}
+
+// Category returns the category t belongs to, or an empty string for types that do not describe a kind of business or amenity, such as locality or point_of_interest.
+func (t FeatureType) Category() Category {
+	return featureTypes[t].category
+}
+
+// Types returns every feature type in the category, sorted by name.
+func (c Category) Types() []FeatureType {
+	var types []FeatureType
+	for t, info := range featureTypes {
+		if info.category == c {
+			types = append(types, t)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
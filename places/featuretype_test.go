@@ -0,0 +1,141 @@
+package places
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseFeatureType(t *testing.T) {
+	for _, test := range []struct {
+		Value string
+		Want  FeatureType
+		Err   error
+	}{
+		{"cafe", Cafe, nil},
+		{" Sublocality_Level_1 ", SublocalityLevel1, nil},
+		{"point_of_interest", PointOfInterest, nil},
+		{"pizzeria", "", errUnknownType},
+		{"", "", errUnknownType},
+	} {
+		got, err := ParseFeatureType(test.Value)
+		if got != test.Want || err != test.Err {
+			t.Errorf("ParseFeatureType(%q) = %q, %v, want %q, %v", test.Value, got, err, test.Want, test.Err)
+		}
+	}
+}
+
+func TestFeatureTypeClass(t *testing.T) {
+	for _, test := range []struct {
+		Type       FeatureType
+		Class      string
+		Searchable bool
+		Category   Category
+	}{
+		{Restaurant, "searchable", true, CategoryFood},
+		{SubwayStation, "searchable", true, CategoryTransit},
+		{Establishment, "response-only", false, ""},
+		{Health, "response-only", false, CategoryHealth},
+		{Locality, "response-only|address-component", false, ""},
+		{StreetNumber, "response-only|address-component", false, ""},
+		{"unicorn_stable", "unknown", false, ""},
+	} {
+		if got := test.Type.Class().String(); got != test.Class {
+			t.Errorf("%s.Class() = %s, want %s", test.Type, got, test.Class)
+		}
+		if got := test.Type.Searchable(); got != test.Searchable {
+			t.Errorf("%s.Searchable() = %v, want %v", test.Type, got, test.Searchable)
+		}
+		if got := test.Type.Category(); got != test.Category {
+			t.Errorf("%s.Category() = %q, want %q", test.Type, got, test.Category)
+		}
+	}
+}
+
+func TestCategoryTypes(t *testing.T) {
+	want := "[campground lodging rv_park]"
+	if got := fmt.Sprint(CategoryLodging.Types()); got != want {
+		t.Errorf("CategoryLodging.Types() = %s, want %s", got, want)
+	}
+
+	searchable, responseOnly := 0, 0
+	for typ, info := range featureTypes {
+		if info.class&ResponseOnly != 0 {
+			responseOnly++
+		}
+		if info.class&Searchable != 0 {
+			searchable++
+			if info.category == "" {
+				t.Errorf("searchable type %s has no category", typ)
+			}
+		}
+		if info.class&Searchable != 0 && info.class&ResponseOnly != 0 {
+			t.Errorf("type %s is both searchable and response-only", typ)
+		}
+	}
+	if searchable != 97 {
+		t.Errorf("found %d searchable types, want 97", searchable)
+	}
+	if responseOnly != 45 {
+		t.Errorf("found %d response-only types, want 45", responseOnly)
+	}
+}
+
+// documentedSearchableTypes lists the types the Places API documents as accepted by the type filter of a search.
+var documentedSearchableTypes = strings.Fields(`
+	accounting airport amusement_park aquarium art_gallery atm bakery bank bar beauty_salon
+	bicycle_store book_store bowling_alley bus_station cafe campground car_dealer car_rental
+	car_repair car_wash casino cemetery church city_hall clothing_store convenience_store
+	courthouse dentist department_store doctor drugstore electrician electronics_store embassy
+	fire_station florist funeral_home furniture_store gas_station gym hair_care hardware_store
+	hindu_temple home_goods_store hospital insurance_agency jewelry_store laundry lawyer library
+	light_rail_station liquor_store local_government_office locksmith lodging meal_delivery
+	meal_takeaway mosque movie_rental movie_theater moving_company museum night_club painter park
+	parking pet_store pharmacy physiotherapist plumber police post_office primary_school
+	real_estate_agency restaurant roofing_contractor rv_park school secondary_school shoe_store
+	shopping_mall spa stadium storage store subway_station supermarket synagogue taxi_stand
+	tourist_attraction train_station transit_station travel_agency university veterinary_care zoo
+`)
+
+func TestDocumentedTypesSearchable(t *testing.T) {
+	for _, name := range documentedSearchableTypes {
+		call := dummyService.Nearby(0, 0)
+		call.Radius = 1000
+		call.Type = FeatureType(name)
+		if err := call.validate(); err != nil {
+			t.Errorf("Nearby search with type %s: %v", name, err)
+		}
+		if FeatureType(name).Category() == "" {
+			t.Errorf("%s has no category", name)
+		}
+	}
+}
+
+// documentedResponseOnlyTypes lists the types the Places API documents as only returned in results.
+var documentedResponseOnlyTypes = strings.Fields(`
+	administrative_area_level_1 administrative_area_level_2 administrative_area_level_3
+	administrative_area_level_4 administrative_area_level_5 administrative_area_level_6
+	administrative_area_level_7 archipelago colloquial_area continent country establishment
+	finance floor food general_contractor geocode health intersection landmark locality
+	natural_feature neighborhood place_of_worship plus_code point_of_interest political post_box
+	postal_code postal_code_prefix postal_code_suffix postal_town premise room route
+	street_address street_number sublocality sublocality_level_1 sublocality_level_2
+	sublocality_level_3 sublocality_level_4 sublocality_level_5 subpremise town_square
+`)
+
+func TestDocumentedTypesResponseOnly(t *testing.T) {
+	for _, name := range documentedResponseOnlyTypes {
+		typ, err := ParseFeatureType(name)
+		if err != nil {
+			t.Errorf("ParseFeatureType(%q) = %v", name, err)
+			continue
+		}
+		call := dummyService.Nearby(0, 0)
+		call.Radius = 1000
+		call.Type = typ
+		if err := call.validate(); !errors.Is(err, errResponseOnlyType) {
+			t.Errorf("Nearby search with type %s = %v, want %v", name, err, errResponseOnlyType)
+		}
+	}
+}
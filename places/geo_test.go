@@ -0,0 +1,25 @@
+package places
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	for _, test := range []struct {
+		Name string
+		A, B LatLng
+		Want float64
+	}{
+		{"same point", LatLng{51.5007, -0.1246}, LatLng{51.5007, -0.1246}, 0},
+		{"London to Paris", LatLng{51.5074, -0.1278}, LatLng{48.8566, 2.3522}, 343560},
+		{"across the antimeridian", LatLng{0, 179.5}, LatLng{0, -179.5}, 111195},
+		{"Sydney to Buenos Aires", LatLng{-33.8688, 151.2093}, LatLng{-34.6037, -58.3816}, 11801000},
+		{"antipodes", LatLng{0, 0}, LatLng{0, 180}, math.Pi * earthRadius},
+	} {
+		got := Distance(test.A, test.B)
+		if math.Abs(got-test.Want) > test.Want*0.001+1 {
+			t.Errorf("Distance() %s = %.0f, want %.0f", test.Name, got, test.Want)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package places
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var errNoAvailableKeys = errors.New("every key in the pool is benched")
+
+// DefaultBenchDuration is how long a KeyPool stops using a key after it returns OVER_QUERY_LIMIT or REQUEST_DENIED.
+const DefaultBenchDuration = time.Minute
+
+// KeyStrategy determines how a KeyPool chooses the key for the next request.
+type KeyStrategy int
+
+const (
+	// RoundRobin cycles through the keys in the order they were added.
+	RoundRobin KeyStrategy = iota
+	// Weighted spreads requests across keys in proportion to their weights.
+	Weighted
+)
+
+// KeyPool distributes requests across several API keys, e.g. keys belonging to different projects. Keys that are over their quota or have been denied are benched for a while and the request is retried with the next key.
+//
+// A KeyPool is safe for concurrent use.
+type KeyPool struct {
+	mu       sync.Mutex
+	strategy KeyStrategy
+	bench    time.Duration
+	keys     []*poolKey
+	next     int
+
+	now func() time.Time
+}
+
+type poolKey struct {
+	key    string
+	weight int
+
+	// current is the running score used by the smooth weighted round-robin in pickWeighted.
+	current int
+
+	requests     int
+	failures     int
+	lastStatus   string
+	benchedUntil time.Time
+}
+
+// KeyStats reports the health and usage of a single key in a KeyPool.
+type KeyStats struct {
+	Key    string
+	Weight int
+	// The number of requests sent using this key.
+	Requests int
+	// The number of those requests that failed. ZERO_RESULTS is not counted as a failure.
+	Failures int
+	// The status of the most recent response, or the error message if the request did not complete.
+	LastStatus string
+	// Whether the key is currently benched, and until when.
+	Benched      bool
+	BenchedUntil time.Time
+}
+
+// NewKeyPool creates a pool from the given keys, each with a weight of 1.
+func NewKeyPool(strategy KeyStrategy, keys ...string) *KeyPool {
+	p := &KeyPool{
+		strategy: strategy,
+		bench:    DefaultBenchDuration,
+		now:      time.Now,
+	}
+	for _, key := range keys {
+		p.Add(key, 1)
+	}
+	return p
+}
+
+// Add adds a key to the pool. Weights below 1 are treated as 1 and are only used by the Weighted strategy.
+func (p *KeyPool) Add(key string, weight int) {
+	if weight < 1 {
+		weight = 1
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys = append(p.keys, &poolKey{key: key, weight: weight})
+}
+
+// SetBenchDuration sets how long a failing key is left out of rotation.
+func (p *KeyPool) SetBenchDuration(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.bench = d
+}
+
+// Len returns the number of keys in the pool, benched or not.
+func (p *KeyPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.keys)
+}
+
+// Stats returns a snapshot of the health and usage counters of every key, in the order they were added.
+func (p *KeyPool) Stats() []KeyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	stats := make([]KeyStats, len(p.keys))
+	for i, k := range p.keys {
+		stats[i] = KeyStats{
+			Key:          k.key,
+			Weight:       k.weight,
+			Requests:     k.requests,
+			Failures:     k.failures,
+			LastStatus:   k.lastStatus,
+			Benched:      now.Before(k.benchedUntil),
+			BenchedUntil: k.benchedUntil,
+		}
+	}
+	return stats
+}
+
+// pick chooses the key for the next request and counts the request, whose outcome must be passed to report.
+func (p *KeyPool) pick() (string, error) {
+	return p.choose(true)
+}
+
+// peek chooses the key for the next request like pick, but doesn't count it, for requests whose outcome the pool never learns.
+func (p *KeyPool) peek() (string, error) {
+	return p.choose(false)
+}
+
+func (p *KeyPool) choose(count bool) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	var k *poolKey
+	switch p.strategy {
+	case Weighted:
+		k = p.pickWeighted(now)
+	default:
+		k = p.pickRoundRobin(now)
+	}
+	if k == nil {
+		return "", errNoAvailableKeys
+	}
+	if count {
+		k.requests++
+	}
+	return k.key, nil
+}
+
+func (p *KeyPool) pickRoundRobin(now time.Time) *poolKey {
+	for i := 0; i < len(p.keys); i++ {
+		k := p.keys[(p.next+i)%len(p.keys)]
+		if now.Before(k.benchedUntil) {
+			continue
+		}
+		p.next = (p.next + i + 1) % len(p.keys)
+		return k
+	}
+	return nil
+}
+
+// pickWeighted implements the smooth weighted round-robin used by nginx, which interleaves keys instead of sending bursts to the heaviest one.
+func (p *KeyPool) pickWeighted(now time.Time) *poolKey {
+	var best *poolKey
+	total := 0
+	for _, k := range p.keys {
+		if now.Before(k.benchedUntil) {
+			continue
+		}
+		k.current += k.weight
+		total += k.weight
+		if best == nil || k.current > best.current {
+			best = k
+		}
+	}
+	if best != nil {
+		best.current -= total
+	}
+	return best
+}
+
+// report records the outcome of a request made with key, benching it if the API rejected it.
+func (p *KeyPool) report(key string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, k := range p.keys {
+		if k.key != key {
+			continue
+		}
+		switch e := err.(type) {
+		case nil:
+			k.lastStatus = "OK"
+		case *apiError:
+			k.lastStatus = e.Status
+			if e.Status != "ZERO_RESULTS" {
+				k.failures++
+			}
+		default:
+			k.lastStatus = e.Error()
+			k.failures++
+		}
+		if IsOverQueryLimit(err) || IsRequestDenied(err) {
+			k.benchedUntil = p.now().Add(p.bench)
+		}
+		return
+	}
+}
+
+// IsNoAvailableKeys returns true if the request was not sent because every key in the service's KeyPool is benched.
+func IsNoAvailableKeys(err error) bool {
+	return err == errNoAvailableKeys
+}
@@ -0,0 +1,118 @@
+package places
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestKeyPoolRoundRobin(t *testing.T) {
+	pool := NewKeyPool(RoundRobin, "a", "b", "c")
+
+	var got []string
+	for i := 0; i < 6; i++ {
+		key, err := pool.pick()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, key)
+	}
+
+	if fmt.Sprint(got) != "[a b c a b c]" {
+		t.Errorf("KeyPool{}.pick() = %v, want [a b c a b c]", got)
+	}
+}
+
+func TestKeyPoolWeighted(t *testing.T) {
+	pool := NewKeyPool(Weighted)
+	pool.Add("a", 5)
+	pool.Add("b", 1)
+	pool.Add("c", 1)
+
+	counts := map[string]int{}
+	for i := 0; i < 70; i++ {
+		key, err := pool.pick()
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts[key]++
+	}
+
+	if counts["a"] != 50 || counts["b"] != 10 || counts["c"] != 10 {
+		t.Errorf("KeyPool{}.pick() distribution = %v, want a:50 b:10 c:10", counts)
+	}
+}
+
+func TestKeyPoolBench(t *testing.T) {
+	now := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	pool := NewKeyPool(RoundRobin, "a", "b")
+	pool.now = func() time.Time { return now }
+
+	pool.report("a", &apiError{Status: "OVER_QUERY_LIMIT"})
+	pool.report("b", &apiError{Status: "REQUEST_DENIED"})
+
+	if _, err := pool.pick(); !IsNoAvailableKeys(err) {
+		t.Errorf("KeyPool{}.pick() with all keys benched = %v, want errNoAvailableKeys", err)
+	}
+
+	now = now.Add(DefaultBenchDuration)
+	if key, err := pool.pick(); err != nil || key != "a" {
+		t.Errorf("KeyPool{}.pick() after bench expired = %q, %v, want a", key, err)
+	}
+}
+
+func TestServiceKeyFailover(t *testing.T) {
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("key")
+		keys = append(keys, key)
+		switch key {
+		case "exhausted":
+			fmt.Fprint(w, `{"status": "OVER_QUERY_LIMIT", "error_message": "You have exceeded your daily request quota."}`)
+		case "revoked":
+			fmt.Fprint(w, `{"status": "REQUEST_DENIED"}`)
+		default:
+			fmt.Fprint(w, `{"status": "OK", "result": {"name": "Google"}}`)
+		}
+	}))
+	defer ts.Close()
+
+	pool := NewKeyPool(RoundRobin, "exhausted", "revoked", "good")
+	service := NewService(http.DefaultClient, "", WithBaseURL(ts.URL), WithKeyPool(pool))
+
+	resp, err := service.Details("place").Do()
+	if err != nil {
+		t.Fatalf("DetailsCall{}.Do() = %v, want nil", err)
+	}
+	if resp.Result.Name != "Google" {
+		t.Errorf("DetailsCall{}.Do().Result.Name = %q, want Google", resp.Result.Name)
+	}
+	if resp.ErrorMessage != "" {
+		t.Errorf("DetailsCall{}.Do().ErrorMessage = %q from a failed-over attempt, want empty", resp.ErrorMessage)
+	}
+	if fmt.Sprint(keys) != "[exhausted revoked good]" {
+		t.Errorf("keys used = %v, want [exhausted revoked good]", keys)
+	}
+
+	stats := pool.Stats()
+	for i, want := range []KeyStats{
+		{Key: "exhausted", Requests: 1, Failures: 1, LastStatus: "OVER_QUERY_LIMIT", Benched: true},
+		{Key: "revoked", Requests: 1, Failures: 1, LastStatus: "REQUEST_DENIED", Benched: true},
+		{Key: "good", Requests: 1, Failures: 0, LastStatus: "OK", Benched: false},
+	} {
+		got := stats[i]
+		if got.Key != want.Key || got.Requests != want.Requests || got.Failures != want.Failures ||
+			got.LastStatus != want.LastStatus || got.Benched != want.Benched {
+			t.Errorf("KeyPool{}.Stats()[%d] = %+v, want %+v", i, got, want)
+		}
+	}
+
+	if _, err := service.Details("place").Do(); err != nil {
+		t.Errorf("DetailsCall{}.Do() with benched keys = %v, want nil", err)
+	}
+	if keys[len(keys)-1] != "good" {
+		t.Errorf("benched key was used: %v", keys)
+	}
+}
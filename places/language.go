@@ -0,0 +1,64 @@
+package places
+
+import (
+	"errors"
+	"strings"
+)
+
+var errUnsupportedLanguage = errors.New("the language is not supported by the Places API")
+
+// supportedLanguages lists the language codes accepted by the Places API, as published at https://developers.google.com/maps/faq#languagesupport.
+var supportedLanguages = map[string]bool{}
+
+func init() {
+	for _, code := range []string{
+		"af", "am", "ar", "az", "be", "bg", "bn", "bs", "ca", "cs", "da", "de", "el",
+		"en", "en-AU", "en-GB", "es", "es-419", "et", "eu", "fa", "fi", "fil", "fr", "fr-CA",
+		"gl", "gu", "hi", "hr", "hu", "hy", "id", "is", "it", "iw", "ja", "ka", "kk", "km",
+		"kn", "ko", "ky", "lo", "lt", "lv", "mk", "ml", "mn", "mr", "ms", "my", "ne", "nl",
+		"no", "pa", "pl", "pt", "pt-BR", "pt-PT", "ro", "ru", "si", "sk", "sl", "sq", "sr",
+		"sv", "sw", "ta", "te", "th", "tr", "uk", "ur", "uz", "vi", "zh", "zh-CN", "zh-HK",
+		"zh-TW", "zu",
+	} {
+		supportedLanguages[strings.ToLower(code)] = true
+	}
+}
+
+// IsSupportedLanguage returns true if code is one of the language codes supported by the Places API, or a BCP-47 regional variant of one such as "en-US", which the API also accepts. The comparison ignores case.
+func IsSupportedLanguage(code string) bool {
+	code = strings.ToLower(code)
+	if supportedLanguages[code] {
+		return true
+	}
+	subtags := strings.Split(code, "-")
+	if len(subtags) < 2 || !supportedLanguages[subtags[0]] {
+		return false
+	}
+	for _, subtag := range subtags[1:] {
+		if !isSubtag(subtag) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSubtag reports whether s is a well-formed BCP-47 subtag: one to eight letters or digits.
+func isSubtag(s string) bool {
+	if len(s) == 0 || len(s) > 8 {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// validateLanguage returns errUnsupportedLanguage if language is set but not supported.
+func validateLanguage(language string) error {
+	if language != "" && !IsSupportedLanguage(language) {
+		return errUnsupportedLanguage
+	}
+	return nil
+}
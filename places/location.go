@@ -0,0 +1,67 @@
+package places
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+var (
+	errLatitudeOutOfRange  = errors.New("latitude must be between -90 and 90 degrees")
+	errLongitudeOutOfRange = errors.New("longitude must be between -180 and 180 degrees")
+)
+
+// locationPrecision is the number of decimal places sent for each coordinate, about 10 cm at the equator.
+const locationPrecision = 6
+
+// Location is a latitude/longitude search parameter. Unlike LatLng it records whether it has been set, so the zero value means "no location" while NewLocation(0, 0) is the point where the equator meets the prime meridian.
+type Location struct {
+	lat, lng float64
+	set      bool
+}
+
+// NewLocation returns a Location at the given latitude and longitude in degrees.
+func NewLocation(lat, lng float64) Location {
+	return Location{lat: lat, lng: lng, set: true}
+}
+
+// IsSet reports whether the location was created with NewLocation.
+func (l Location) IsSet() bool {
+	return l.set
+}
+
+// LatLng returns the coordinates of the location.
+func (l Location) LatLng() LatLng {
+	return LatLng{Lat: l.lat, Lng: l.lng}
+}
+
+// String formats the location as the API expects it, e.g. "-33.867000,151.195800", or returns an empty string if it is not set.
+func (l Location) String() string {
+	if !l.set {
+		return ""
+	}
+	return formatCoordinate(l.lat) + "," + formatCoordinate(l.lng)
+}
+
+// validate checks that the coordinates are within range. An unset location is valid.
+func (l Location) validate() error {
+	if !l.set {
+		return nil
+	}
+	if math.IsNaN(l.lat) || l.lat < -90 || l.lat > 90 {
+		return errLatitudeOutOfRange
+	}
+	if math.IsNaN(l.lng) || l.lng < -180 || l.lng > 180 {
+		return errLongitudeOutOfRange
+	}
+	return nil
+}
+
+func formatCoordinate(v float64) string {
+	s := strconv.FormatFloat(v, 'f', locationPrecision, 64)
+	// Values that round to zero from below would otherwise be sent as "-0.000000".
+	if s == "-"+strconv.FormatFloat(0, 'f', locationPrecision, 64) {
+		return s[1:]
+	}
+	return s
+}
@@ -0,0 +1,43 @@
+package places
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLocationString(t *testing.T) {
+	for _, test := range []struct {
+		Location Location
+		Want     string
+	}{
+		{Location{}, ""},
+		{NewLocation(0, 0), "0.000000,0.000000"},
+		{NewLocation(37.7833, -122.4167), "37.783300,-122.416700"},
+		{NewLocation(-33.8670522, 151.1957362), "-33.867052,151.195736"},
+		{NewLocation(-0.0000001, -0.0000004), "0.000000,0.000000"},
+		{NewLocation(-90, 180), "-90.000000,180.000000"},
+	} {
+		if got := test.Location.String(); got != test.Want {
+			t.Errorf("Location{%v}.String() = %q, want %q", test.Location.LatLng(), got, test.Want)
+		}
+	}
+}
+
+func TestLocationValidate(t *testing.T) {
+	for _, test := range []struct {
+		Location Location
+		Want     error
+	}{
+		{Location{}, nil},
+		{NewLocation(0, 0), nil},
+		{NewLocation(-90, -180), nil},
+		{NewLocation(90.1, 0), errLatitudeOutOfRange},
+		{NewLocation(-91, 0), errLatitudeOutOfRange},
+		{NewLocation(0, 180.5), errLongitudeOutOfRange},
+		{NewLocation(math.NaN(), 0), errLatitudeOutOfRange},
+	} {
+		if got := test.Location.validate(); got != test.Want {
+			t.Errorf("Location{%v}.validate() = %v, want %v", test.Location.LatLng(), got, test.Want)
+		}
+	}
+}
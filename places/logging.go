@@ -0,0 +1,45 @@
+package places
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"time"
+)
+
+type logLevels struct {
+	ok, failed slog.Level
+}
+
+var defaultLogLevels = &logLevels{ok: slog.LevelDebug, failed: slog.LevelWarn}
+
+// logAttempt logs a single HTTP request made for a call.
+func (s *Service) logAttempt(ctx context.Context, endpoint string, query url.Values, attempt int, data apiResponse, err error, duration time.Duration) {
+	levels := s.logLevels
+	if levels == nil {
+		levels = defaultLogLevels
+	}
+	level := levels.ok
+	if err != nil && !IsZeroResults(err) {
+		level = levels.failed
+	}
+
+	if !s.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("endpoint", endpoint),
+		// The query is logged as the call built it, before the credentials were added to the request.
+		slog.String("params", query.Encode()),
+		slog.String("status", statusOf(err)),
+		slog.Int("results", data.resultCount()),
+		slog.Duration("duration", duration),
+		slog.Int("attempt", attempt),
+		slog.Bool("next_page_token", data.nextPageToken() != ""),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	s.logger.LogAttrs(ctx, level, "places request", attrs...)
+}
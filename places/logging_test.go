@@ -0,0 +1,76 @@
+package places
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServiceLogger(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("key") {
+		case "exhausted":
+			fmt.Fprint(w, `{"status": "OVER_QUERY_LIMIT"}`)
+		default:
+			fmt.Fprint(w, `{"status": "OK", "results": [{"name": "Google"}], "next_page_token": "token"}`)
+		}
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	service := NewService(http.DefaultClient, "",
+		WithBaseURL(ts.URL),
+		WithKeyPool(NewKeyPool(RoundRobin, "exhausted", "good")),
+		WithLogger(logger),
+	)
+
+	if _, err := service.TextSearch("Google").Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(buf.String(), "exhausted") || strings.Contains(buf.String(), "good") {
+		t.Errorf("log contains an API key:\n%s", buf.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("logged %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+
+	for i, want := range []map[string]interface{}{
+		{"level": "WARN", "status": "OVER_QUERY_LIMIT", "attempt": 1.0, "results": 0.0, "next_page_token": false},
+		{"level": "DEBUG", "status": "OK", "attempt": 2.0, "results": 1.0, "next_page_token": true},
+	} {
+		var got map[string]interface{}
+		if err := json.Unmarshal([]byte(lines[i]), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got["endpoint"] != "textsearch" || got["params"] != "query=Google" {
+			t.Errorf("line %d: endpoint, params = %v, %v, want textsearch, query=Google", i, got["endpoint"], got["params"])
+		}
+		for k, v := range want {
+			if got[k] != v {
+				t.Errorf("line %d: %s = %v, want %v", i, k, got[k], v)
+			}
+		}
+	}
+
+	buf.Reset()
+	service = NewService(http.DefaultClient, "good",
+		WithBaseURL(ts.URL),
+		WithLogger(logger),
+		WithLogLevels(slog.LevelDebug-4, slog.LevelError),
+	)
+	if _, err := service.TextSearch("Google").Do(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("logged below the handler's level:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,254 @@
+package places
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// placeField is a field of PlaceDetails that is sent by the API.
+type placeField struct {
+	// name is the JSON name of the field, e.g. "rating".
+	name  string
+	index int
+}
+
+// placeFields lists the fields of PlaceDetails in the order they are declared.
+var placeFields = func() []placeField {
+	var fields []placeField
+	t := reflect.TypeOf(PlaceDetails{})
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		fields = append(fields, placeField{name: strings.Split(tag, ",")[0], index: i})
+	}
+	return fields
+}()
+
+// UnmarshalJSON decodes a place as usual, remembering which fields were present in data.
+func (p *PlaceDetails) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	type plain PlaceDetails
+	if err := json.Unmarshal(data, (*plain)(p)); err != nil {
+		return err
+	}
+	if fields == nil {
+		return nil
+	}
+	p.present = make(map[string]bool, len(fields))
+	for name, value := range fields {
+		if string(value) != "null" {
+			p.present[name] = true
+		}
+	}
+	return nil
+}
+
+// unmarshalResult decodes data into a place and into extra, the fields a result type adds to the place it embeds. The added fields are not recorded as fields of the place.
+func unmarshalResult(data []byte, p *PlaceDetails, extra interface{}) error {
+	if err := p.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, extra); err != nil {
+		return err
+	}
+	t := reflect.TypeOf(extra).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		delete(p.present, strings.Split(t.Field(i).Tag.Get("json"), ",")[0])
+	}
+	return nil
+}
+
+// Has reports whether the place has the field with the given JSON name, e.g. "rating". A field has a value if it was present in the response the place was decoded from, even if its value was zero, or if it has been set to a non-zero value since.
+func (p *PlaceDetails) Has(field string) bool {
+	if p.present[field] {
+		return true
+	}
+	for _, f := range placeFields {
+		if f.name == field {
+			return !reflect.ValueOf(p).Elem().Field(f.index).IsZero()
+		}
+	}
+	return false
+}
+
+// Fields returns the JSON names of the fields the place has, in the order they are declared in PlaceDetails.
+func (p *PlaceDetails) Fields() []string {
+	var names []string
+	for _, f := range placeFields {
+		if p.Has(f.name) {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// Merge updates p with the fields of other, which is taken to be the newer or more complete record, e.g. a Details result for a place found by a search. Fields other does not have are kept, and fields it has replace those of p even if their value is zero, with these exceptions:
+//
+//   - Types, Photos, Reviews and AltIDs are combined: the items of other come first, followed by those of p that other does not have.
+//   - In OpeningHours and CurrentOpeningHours, OpenNow is taken from other but the periods, weekday text and special days are only replaced if other has some, as search results only say whether a place is open now.
+func (p *PlaceDetails) Merge(other *PlaceDetails) {
+	present := make(map[string]bool, len(placeFields))
+	for _, name := range p.Fields() {
+		present[name] = true
+	}
+
+	dst, src := reflect.ValueOf(p).Elem(), reflect.ValueOf(other).Elem()
+	for _, f := range placeFields {
+		if !other.Has(f.name) {
+			continue
+		}
+		present[f.name] = true
+		switch f.name {
+		case "types":
+			p.Types = mergeTypes(other.Types, p.Types)
+		case "photos":
+			p.Photos = mergePhotos(other.Photos, p.Photos)
+		case "reviews":
+			p.Reviews = mergeReviews(other.Reviews, p.Reviews)
+		case "alt_ids":
+			p.AltIDs = mergeAltIDs(other.AltIDs, p.AltIDs)
+		case "opening_hours":
+			p.OpeningHours = mergeHours(p.OpeningHours, other.OpeningHours)
+		case "current_opening_hours":
+			p.CurrentOpeningHours = mergeHours(p.CurrentOpeningHours, other.CurrentOpeningHours)
+		default:
+			dst.Field(f.index).Set(src.Field(f.index))
+		}
+	}
+	// The map may be shared with copies of p, so it is replaced rather than updated.
+	p.present = present
+}
+
+func mergeTypes(newer, older []FeatureType) []FeatureType {
+	merged := append([]FeatureType(nil), newer...)
+	for _, t := range older {
+		if !containsType(newer, t) {
+			merged = append(merged, t)
+		}
+	}
+	return merged
+}
+
+func containsType(types []FeatureType, t FeatureType) bool {
+	for _, have := range types {
+		if have == t {
+			return true
+		}
+	}
+	return false
+}
+
+func mergePhotos(newer, older []Photo) []Photo {
+	merged := append([]Photo(nil), newer...)
+	seen := map[string]bool{}
+	for _, photo := range newer {
+		seen[photo.PhotoReference] = true
+	}
+	for _, photo := range older {
+		if !seen[photo.PhotoReference] {
+			merged = append(merged, photo)
+		}
+	}
+	return merged
+}
+
+// mergeReviews combines two lists of reviews, identifying reviews by their author and time.
+func mergeReviews(newer, older []*Review) []*Review {
+	type key struct {
+		author string
+		time   int
+	}
+	merged := append([]*Review(nil), newer...)
+	seen := map[key]bool{}
+	for _, r := range newer {
+		seen[key{r.AuthorName, r.Time}] = true
+	}
+	for _, r := range older {
+		if !seen[key{r.AuthorName, r.Time}] {
+			merged = append(merged, r)
+		}
+	}
+	return merged
+}
+
+func mergeAltIDs(newer, older []AltID) []AltID {
+	merged := append([]AltID(nil), newer...)
+	seen := map[string]bool{}
+	for _, alt := range newer {
+		seen[alt.PlaceID] = true
+	}
+	for _, alt := range older {
+		if !seen[alt.PlaceID] {
+			merged = append(merged, alt)
+		}
+	}
+	return merged
+}
+
+func mergeHours(older, newer OpeningHours) OpeningHours {
+	merged := older
+	merged.OpenNow = newer.OpenNow
+	if len(newer.Periods) > 0 {
+		merged.Periods = newer.Periods
+	}
+	if len(newer.WeekdayText) > 0 {
+		merged.WeekdayText = newer.WeekdayText
+	}
+	if len(newer.SpecialDays) > 0 {
+		merged.SpecialDays = newer.SpecialDays
+	}
+	if newer.Type != "" {
+		merged.Type = newer.Type
+	}
+	return merged
+}
+
+// FieldChange describes a field whose value differs between two places.
+type FieldChange struct {
+	// Field is the JSON name of the field, e.g. "rating".
+	Field string
+	// Old and New are the values of the field in each place, or nil if the place does not have the field. Pointer fields such as PriceLevel are dereferenced.
+	Old, New interface{}
+}
+
+// Diff returns the fields whose value differs between p and other, in the order they are declared in PlaceDetails. A field that one place has and the other does not is a change, even if its value is zero, e.g. a rating that is no longer returned.
+func (p *PlaceDetails) Diff(other *PlaceDetails) []FieldChange {
+	var changes []FieldChange
+	a, b := reflect.ValueOf(p).Elem(), reflect.ValueOf(other).Elem()
+	for _, f := range placeFields {
+		hasOld, hasNew := p.Has(f.name), other.Has(f.name)
+		if !hasOld && !hasNew {
+			continue
+		}
+		before, after := a.Field(f.index), b.Field(f.index)
+		if hasOld == hasNew && reflect.DeepEqual(before.Interface(), after.Interface()) {
+			continue
+		}
+		change := FieldChange{Field: f.name}
+		if hasOld {
+			change.Old = fieldValue(before)
+		}
+		if hasNew {
+			change.New = fieldValue(after)
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// fieldValue returns the value of v, dereferencing it if it is a pointer.
+func fieldValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	return v.Interface()
+}
@@ -0,0 +1,138 @@
+package places
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func decodePlace(t *testing.T, data string) PlaceDetails {
+	t.Helper()
+	var p PlaceDetails
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestPlaceDetailsHas(t *testing.T) {
+	decoded := decodePlace(t, `{"place_id": "a", "rating": 0, "photos": [], "website": null}`)
+	built := PlaceDetails{PlaceID: "b", Rating: 4}
+	for _, test := range []struct {
+		Name  string
+		Place PlaceDetails
+		Field string
+		Want  bool
+	}{
+		{"zero value sent", decoded, "rating", true},
+		{"empty list sent", decoded, "photos", true},
+		{"null", decoded, "website", false},
+		{"missing", decoded, "vicinity", false},
+		{"set in code", built, "rating", true},
+		{"zero in code", built, "vicinity", false},
+		{"unknown field", decoded, "colour", false},
+	} {
+		if got := test.Place.Has(test.Field); got != test.Want {
+			t.Errorf("%s: Has(%q) = %v, want %v", test.Name, test.Field, got, test.Want)
+		}
+	}
+
+	decoded.Vicinity = "1 Main St"
+	if got := strings.Join(decoded.Fields(), " "); got != "photos place_id rating vicinity" {
+		t.Errorf("Fields() = %q", got)
+	}
+}
+
+func TestPlaceDetailsMerge(t *testing.T) {
+	search := decodePlace(t, `{
+		"place_id": "a",
+		"name": "Cafe",
+		"vicinity": "1 Main St",
+		"rating": 4.2,
+		"types": ["cafe", "food"],
+		"photos": [{"photo_reference": "p1"}],
+		"opening_hours": {"open_now": true}
+	}`)
+	details := decodePlace(t, `{
+		"place_id": "a",
+		"name": "Cafe Nero",
+		"rating": 0,
+		"types": ["cafe", "store"],
+		"photos": [{"photo_reference": "p2"}, {"photo_reference": "p1"}],
+		"opening_hours": {"open_now": false, "periods": [{"open": {"day": 0, "time": "0800"}}]}
+	}`)
+	original := search
+	originalFields := fmt.Sprint(original.Fields())
+
+	search.Merge(&details)
+	for _, test := range []struct {
+		Field string
+		Got   interface{}
+		Want  interface{}
+	}{
+		{"name", search.Name, "Cafe Nero"},
+		{"vicinity", search.Vicinity, "1 Main St"},
+		{"rating", search.Rating, 0.0},
+		{"types", fmt.Sprint(search.Types), "[cafe store food]"},
+		{"photos", len(search.Photos), 2},
+		{"open_now", search.OpeningHours.OpenNow, false},
+		{"periods", len(search.OpeningHours.Periods), 1},
+	} {
+		if test.Got != test.Want {
+			t.Errorf("merged %s = %v, want %v", test.Field, test.Got, test.Want)
+		}
+	}
+	if !search.Has("rating") || !search.Has("vicinity") {
+		t.Errorf("merged fields = %v, want rating and vicinity", search.Fields())
+	}
+
+	// A later search result updates whether the place is open without losing its hours.
+	later := decodePlace(t, `{"place_id": "a", "opening_hours": {"open_now": true}}`)
+	search.Merge(&later)
+	if !search.OpeningHours.OpenNow || len(search.OpeningHours.Periods) != 1 || search.Name != "Cafe Nero" {
+		t.Errorf("after a later search: %+v", search)
+	}
+
+	if fmt.Sprint(original.Fields()) != originalFields || len(original.OpeningHours.Periods) != 0 {
+		t.Errorf("Merge() modified a copy of the place: %+v", original)
+	}
+}
+
+func TestPlaceDetailsDiff(t *testing.T) {
+	moderate, expensive := Moderate, Expensive
+	for _, test := range []struct {
+		Name     string
+		Old, New PlaceDetails
+		Want     string
+	}{
+		{
+			Name: "no change",
+			Old:  PlaceDetails{PlaceID: "a", Types: []FeatureType{Cafe}},
+			New:  PlaceDetails{PlaceID: "a", Types: []FeatureType{Cafe}},
+			Want: "[]",
+		},
+		{
+			Name: "changed values",
+			Old:  PlaceDetails{PlaceID: "a", Name: "Cafe", PriceLevel: &moderate},
+			New:  PlaceDetails{PlaceID: "a", Name: "Cafe Nero", PriceLevel: &expensive},
+			Want: "[{name Cafe Cafe Nero} {price_level 2 3}]",
+		},
+		{
+			Name: "added and removed",
+			Old:  PlaceDetails{PlaceID: "a", Website: "https://cafe.example"},
+			New:  PlaceDetails{PlaceID: "a", Rating: 4.5},
+			Want: "[{rating <nil> 4.5} {website https://cafe.example <nil>}]",
+		},
+		{
+			Name: "zero value sent",
+			Old:  decodePlace(t, `{"place_id": "a"}`),
+			New:  decodePlace(t, `{"place_id": "a", "rating": 0}`),
+			Want: "[{rating <nil> 0}]",
+		},
+	} {
+		if got := fmt.Sprint(test.Old.Diff(&test.New)); got != test.Want {
+			t.Errorf("%s: Diff() = %s, want %s", test.Name, got, test.Want)
+		}
+	}
+}
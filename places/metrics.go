@@ -0,0 +1,196 @@
+package places
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A Collector receives measurements of the requests made by a Service. Implementations must be safe for concurrent use.
+type Collector interface {
+	// ObserveRequest is called once for every call to the API with the endpoint (e.g. "nearbysearch"), the resulting status and how long the call took, including any retries.
+	//
+	// The status is the status returned by the API, such as OK or ZERO_RESULTS, or one of HTTP_<code> for non-200 responses, CIRCUIT_OPEN when the circuit breaker rejected the call and ERROR when the request did not complete.
+	ObserveRequest(endpoint, status string, duration time.Duration)
+	// ObserveRetry is called whenever a call is sent again, with the status that caused the retry.
+	ObserveRetry(endpoint, status string)
+	// ObserveCache is called for every lookup in the service's response cache.
+	ObserveCache(endpoint string, hit bool)
+}
+
+// statusOf returns the status reported to a Collector for the outcome of a call.
+func statusOf(err error) string {
+	switch e := err.(type) {
+	case nil:
+		return "OK"
+	case *apiError:
+		return e.Status
+	case *httpError:
+		return "HTTP_" + strconv.Itoa(e.StatusCode)
+	case *breakerError:
+		return "CIRCUIT_OPEN"
+	}
+	return "ERROR"
+}
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the latency histogram buckets used by Metrics.
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics is a Collector that keeps counters and latency histograms in memory and serves them over HTTP in the Prometheus text exposition format.
+type Metrics struct {
+	buckets []float64
+
+	mu        sync.Mutex
+	requests  map[[2]string]uint64
+	latencies map[string]*histogram
+	retries   map[[2]string]uint64
+	cache     map[[2]string]uint64
+}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// NewMetrics creates an empty Metrics using DefaultLatencyBuckets.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		buckets:   DefaultLatencyBuckets,
+		requests:  make(map[[2]string]uint64),
+		latencies: make(map[string]*histogram),
+		retries:   make(map[[2]string]uint64),
+		cache:     make(map[[2]string]uint64),
+	}
+}
+
+// ObserveRequest implements Collector.
+func (m *Metrics) ObserveRequest(endpoint, status string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[[2]string{endpoint, status}]++
+
+	h, ok := m.latencies[endpoint]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.latencies[endpoint] = h
+	}
+	seconds := duration.Seconds()
+	for i, le := range m.buckets {
+		if seconds <= le {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// ObserveRetry implements Collector.
+func (m *Metrics) ObserveRetry(endpoint, status string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries[[2]string{endpoint, status}]++
+}
+
+// ObserveCache implements Collector.
+func (m *Metrics) ObserveCache(endpoint string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cache[[2]string{endpoint, result}]++
+}
+
+// CacheHitRatio returns the fraction of cache lookups for endpoint that were hits, or 0 if there were none.
+func (m *Metrics) CacheHitRatio(endpoint string) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hits := m.cache[[2]string{endpoint, "hit"}]
+	total := hits + m.cache[[2]string{endpoint, "miss"}]
+	if total == 0 {
+		return 0
+	}
+	return float64(hits) / float64(total)
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+
+	m.mu.Lock()
+	writeCounter(&buf, "places_requests_total", "Places API calls by endpoint and status.", "status", m.requests)
+
+	fmt.Fprintf(&buf, "# HELP places_request_duration_seconds Latency of Places API calls, including retries.\n")
+	fmt.Fprintf(&buf, "# TYPE places_request_duration_seconds histogram\n")
+	for _, endpoint := range sortedKeys(m.latencies) {
+		h := m.latencies[endpoint]
+		label := quoteLabel(endpoint)
+		for i, le := range m.buckets {
+			fmt.Fprintf(&buf, "places_request_duration_seconds_bucket{endpoint=%s,le=\"%s\"} %d\n", label, formatFloat(le), h.counts[i])
+		}
+		fmt.Fprintf(&buf, "places_request_duration_seconds_bucket{endpoint=%s,le=\"+Inf\"} %d\n", label, h.count)
+		fmt.Fprintf(&buf, "places_request_duration_seconds_sum{endpoint=%s} %s\n", label, formatFloat(h.sum))
+		fmt.Fprintf(&buf, "places_request_duration_seconds_count{endpoint=%s} %d\n", label, h.count)
+	}
+
+	writeCounter(&buf, "places_retries_total", "Places API calls sent again, by the status that caused the retry.", "status", m.retries)
+	writeCounter(&buf, "places_cache_lookups_total", "Response cache lookups by endpoint and result.", "result", m.cache)
+	m.mu.Unlock()
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+func writeCounter(w io.Writer, name, help, label string, values map[[2]string]uint64) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s counter\n", name)
+
+	keys := make([][2]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	for _, k := range keys {
+		fmt.Fprintf(w, "%s{endpoint=%s,%s=%s} %d\n", name, quoteLabel(k[0]), label, quoteLabel(k[1]), values[k])
+	}
+}
+
+func sortedKeys(m map[string]*histogram) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// quoteLabel quotes a label value using the escaping rules of the exposition format.
+func quoteLabel(v string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v) + `"`
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
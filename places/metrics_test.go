@@ -0,0 +1,118 @@
+package places
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatusOf(t *testing.T) {
+	for _, test := range []struct {
+		Err  error
+		Want string
+	}{
+		{nil, "OK"},
+		{&apiError{Status: "ZERO_RESULTS"}, "ZERO_RESULTS"},
+		{&httpError{StatusCode: 503}, "HTTP_503"},
+		{&breakerError{Endpoint: "details"}, "CIRCUIT_OPEN"},
+		{errors.New("connection refused"), "ERROR"},
+	} {
+		if got := statusOf(test.Err); got != test.Want {
+			t.Errorf("statusOf(%v) = %q, want %q", test.Err, got, test.Want)
+		}
+	}
+}
+
+func TestMetricsWriteTo(t *testing.T) {
+	m := NewMetrics()
+	m.buckets = []float64{0.1, 1}
+	m.ObserveRequest("details", "OK", 50*time.Millisecond)
+	m.ObserveRequest("details", "OK", 500*time.Millisecond)
+	m.ObserveRequest("nearbysearch", "ZERO_RESULTS", 2*time.Second)
+	m.ObserveRetry("details", "OVER_QUERY_LIMIT")
+	m.ObserveCache("details", true)
+	m.ObserveCache("details", true)
+	m.ObserveCache("details", false)
+
+	var b strings.Builder
+	if _, err := m.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP places_requests_total Places API calls by endpoint and status.
+# TYPE places_requests_total counter
+places_requests_total{endpoint="details",status="OK"} 2
+places_requests_total{endpoint="nearbysearch",status="ZERO_RESULTS"} 1
+# HELP places_request_duration_seconds Latency of Places API calls, including retries.
+# TYPE places_request_duration_seconds histogram
+places_request_duration_seconds_bucket{endpoint="details",le="0.1"} 1
+places_request_duration_seconds_bucket{endpoint="details",le="1"} 2
+places_request_duration_seconds_bucket{endpoint="details",le="+Inf"} 2
+places_request_duration_seconds_sum{endpoint="details"} 0.55
+places_request_duration_seconds_count{endpoint="details"} 2
+places_request_duration_seconds_bucket{endpoint="nearbysearch",le="0.1"} 0
+places_request_duration_seconds_bucket{endpoint="nearbysearch",le="1"} 0
+places_request_duration_seconds_bucket{endpoint="nearbysearch",le="+Inf"} 1
+places_request_duration_seconds_sum{endpoint="nearbysearch"} 2
+places_request_duration_seconds_count{endpoint="nearbysearch"} 1
+# HELP places_retries_total Places API calls sent again, by the status that caused the retry.
+# TYPE places_retries_total counter
+places_retries_total{endpoint="details",status="OVER_QUERY_LIMIT"} 1
+# HELP places_cache_lookups_total Response cache lookups by endpoint and result.
+# TYPE places_cache_lookups_total counter
+places_cache_lookups_total{endpoint="details",result="hit"} 2
+places_cache_lookups_total{endpoint="details",result="miss"} 1
+`
+	if b.String() != want {
+		t.Errorf("Metrics{}.WriteTo() =\n%s\nwant\n%s", b.String(), want)
+	}
+
+	if got := m.CacheHitRatio("details"); got != 2.0/3.0 {
+		t.Errorf("Metrics{}.CacheHitRatio() = %v, want 0.666…", got)
+	}
+}
+
+func TestServiceCollector(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("key") {
+		case "exhausted":
+			fmt.Fprint(w, `{"status": "OVER_QUERY_LIMIT"}`)
+		default:
+			fmt.Fprint(w, `{"status": "ZERO_RESULTS", "results": []}`)
+		}
+	}))
+	defer ts.Close()
+
+	metrics := NewMetrics()
+	service := NewService(http.DefaultClient, "",
+		WithBaseURL(ts.URL),
+		WithKeyPool(NewKeyPool(RoundRobin, "exhausted", "good")),
+		WithCollector(metrics),
+	)
+
+	call := service.Nearby(-33.8670522, 151.1957362)
+	call.Radius = 500
+	if _, err := call.Do(); !IsZeroResults(err) {
+		t.Fatalf("NearbyCall{}.Do() = %v, want ZERO_RESULTS", err)
+	}
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		`places_requests_total{endpoint="nearbysearch",status="ZERO_RESULTS"} 1`,
+		`places_retries_total{endpoint="nearbysearch",status="OVER_QUERY_LIMIT"} 1`,
+		`places_request_duration_seconds_count{endpoint="nearbysearch"} 1`,
+	} {
+		if !strings.Contains(body, want+"\n") {
+			t.Errorf("Metrics{}.ServeHTTP() is missing %q:\n%s", want, body)
+		}
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q, want text/plain; version=0.0.4", ct)
+	}
+}
@@ -0,0 +1,259 @@
+package places
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// defaultMonitorInterval is how often a Monitor refreshes each place unless Interval is set.
+const defaultMonitorInterval = 24 * time.Hour
+
+// Monitor watches a set of places for changes, e.g. to alert when one closes or changes its phone number. Run refreshes each place with a Details request once per Interval, spreading the requests evenly across it, compares the result with the last known details using Comparer, and sends any changes to every sink. A place seen for the first time only sets the baseline; a place that is no longer found is reported as removed.
+//
+// The last known details can be saved to a file so that changes made while the monitor is stopped are still reported. A Monitor is safe for concurrent use, but only one Run may be active at a time.
+//
+//	m := service.Monitor(ids...)
+//	m.StatePath = "places.json"
+//	m.Sinks = []places.Sink{places.WebhookSink(http.DefaultClient, alertURL)}
+//	if err := m.Load(); err != nil { ... }
+//	m.Run(ctx)
+type Monitor struct {
+	service *Service
+
+	// Interval is how often each place is refreshed. Zero means once a day.
+	Interval time.Duration
+	// StatePath is the file the last known details of every place are saved to after each refresh and read from by Load. Empty means nothing is saved.
+	StatePath string
+	// Comparer decides which changes are reported.
+	Comparer Comparer
+	// Sinks receive the changes to each place.
+	Sinks []Sink
+	// OnError, if set, is called with the errors from refreshing a place, delivering its changes or saving the state. Run carries on after an error.
+	OnError func(placeID string, err error)
+	// The language code, indicating in which language the details should be returned, if possible. Defaults to the service's language.
+	Language string
+
+	mu    sync.Mutex
+	ids   []string
+	state map[string]monitorEntry
+}
+
+// monitorEntry is the last known state of a watched place, as it is saved to the state file.
+type monitorEntry struct {
+	Place PlaceDetails `json:"place"`
+	// Fields lists the fields the place has, since a saved place would otherwise appear to have every field.
+	Fields  []string  `json:"fields"`
+	Checked time.Time `json:"checked"`
+}
+
+// Monitor returns a Monitor that watches the places with the given IDs.
+func (p *Service) Monitor(ids ...string) *Monitor {
+	m := &Monitor{service: p, state: map[string]monitorEntry{}}
+	m.Add(ids...)
+	return m
+}
+
+// Add starts watching the places with the given IDs. IDs that are already watched are ignored.
+func (m *Monitor) Add(ids ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		if !containsString(m.ids, id) {
+			m.ids = append(m.ids, id)
+		}
+	}
+}
+
+// Remove stops watching the places with the given IDs and forgets their last known details.
+func (m *Monitor) Remove(ids ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		for i, have := range m.ids {
+			if have == id {
+				m.ids = append(m.ids[:i:i], m.ids[i+1:]...)
+				break
+			}
+		}
+		delete(m.state, id)
+	}
+}
+
+// IDs returns the IDs of the watched places.
+func (m *Monitor) IDs() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.ids...)
+}
+
+// Last returns the last known details of the watched place with the given ID.
+func (m *Monitor) Last(id string) (PlaceDetails, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.state[id]
+	return e.Place, ok
+}
+
+// Load reads the last known details of the watched places from StatePath. A missing file is not an error. Places in the file that are not watched are ignored.
+func (m *Monitor) Load() error {
+	if m.StatePath == "" {
+		return nil
+	}
+	data, err := ioutil.ReadFile(m.StatePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var state map[string]monitorEntry
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, e := range state {
+		if containsString(m.ids, id) {
+			e.Place.present = map[string]bool{}
+			for _, name := range e.Fields {
+				e.Place.present[name] = true
+			}
+			m.state[id] = e
+		}
+	}
+	return nil
+}
+
+// Run refreshes the watched places until ctx ends, then returns its error. Each round refreshes the places in the order they were last refreshed, least recently first, so a restarted monitor carries on where it left off.
+func (m *Monitor) Run(ctx context.Context) error {
+	interval := m.Interval
+	if interval <= 0 {
+		interval = defaultMonitorInterval
+	}
+	for {
+		ids := m.schedule()
+		if len(ids) == 0 {
+			if err := sleep(ctx, interval); err != nil {
+				return err
+			}
+			continue
+		}
+		step := interval / time.Duration(len(ids))
+		for _, id := range ids {
+			if _, err := m.Refresh(ctx, id); err != nil && ctx.Err() == nil {
+				m.report(id, err)
+			}
+			if err := sleep(ctx, step); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// schedule returns the watched IDs, least recently refreshed first.
+func (m *Monitor) schedule() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := append([]string(nil), m.ids...)
+	sort.SliceStable(ids, func(i, j int) bool {
+		return m.state[ids[i]].Checked.Before(m.state[ids[j]].Checked)
+	})
+	return ids
+}
+
+// Refresh fetches the details of the watched place with the given ID now, sends any changes to the sinks and saves the state. It returns the changes even if a sink fails, in which case the first sink error is returned.
+func (m *Monitor) Refresh(ctx context.Context, id string) ([]Change, error) {
+	call := m.service.Details(id)
+	call.Language = m.Language
+	resp, err := call.Context(ctx).Do()
+	if err != nil && !IsNotFound(err) {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	last, known := m.state[id]
+	var changes []Change
+	switch {
+	case err != nil:
+		// The place no longer exists, so it is reported once and forgotten.
+		if known {
+			changes = []Change{{Kind: PlaceRemoved, PlaceID: id, Name: last.Place.Name}}
+			delete(m.state, id)
+		}
+	case !containsString(m.ids, id):
+		// The place stopped being watched while it was being refreshed.
+	default:
+		if known {
+			changes = m.Comparer.changes(&last.Place, &resp.Result)
+		}
+		m.state[id] = monitorEntry{
+			Place:   resp.Result,
+			Fields:  resp.Result.Fields(),
+			Checked: time.Now(),
+		}
+	}
+	m.mu.Unlock()
+
+	var sinkErr error
+	if len(changes) > 0 {
+		for _, sink := range m.Sinks {
+			if err := sink.Send(ctx, changes); err != nil && sinkErr == nil {
+				sinkErr = err
+			}
+		}
+	}
+	if err := m.save(); err != nil {
+		m.report(id, err)
+	}
+	return changes, sinkErr
+}
+
+func (m *Monitor) report(id string, err error) {
+	if m.OnError != nil {
+		m.OnError(id, err)
+	}
+}
+
+// save writes the state to StatePath, replacing the file atomically so a crash never leaves it half written.
+func (m *Monitor) save() error {
+	if m.StatePath == "" {
+		return nil
+	}
+	m.mu.Lock()
+	data, err := json.Marshal(m.state)
+	m.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(m.StatePath), filepath.Base(m.StatePath)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), m.StatePath)
+}
+
+func containsString(list []string, s string) bool {
+	for _, have := range list {
+		if have == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,258 @@
+package places
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mutableDetails serves Details responses that can be changed while a test runs.
+type mutableDetails struct {
+	mu      sync.Mutex
+	results map[string]string
+}
+
+func (d *mutableDetails) set(id, result string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if result == "" {
+		delete(d.results, id)
+		return
+	}
+	d.results[id] = result
+}
+
+func (d *mutableDetails) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	detailsByID(d.results)(w, r)
+}
+
+func TestMonitorRefresh(t *testing.T) {
+	details := &mutableDetails{results: map[string]string{
+		"a": `{"place_id": "a", "name": "Clinic", "formatted_phone_number": "555 0100", "business_status": "OPERATIONAL"}`,
+	}}
+	ts := httptest.NewServer(details)
+	defer ts.Close()
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL))
+
+	var got []string
+	ch := make(chan Change, 10)
+	m := service.Monitor("a")
+	m.Sinks = []Sink{
+		SinkFunc(func(ctx context.Context, changes []Change) error {
+			for _, c := range changes {
+				got = append(got, fmt.Sprintf("%s %s %v", c.Kind, c.PlaceID, c.Fields))
+			}
+			return nil
+		}),
+		ChannelSink(ch),
+	}
+	ctx := context.Background()
+
+	if changes, err := m.Refresh(ctx, "a"); err != nil || len(changes) != 0 {
+		t.Fatalf("first Refresh() = %v, %v, want no changes", changes, err)
+	}
+	if last, ok := m.Last("a"); !ok || last.Name != "Clinic" {
+		t.Errorf("Last() = %+v, %v", last, ok)
+	}
+
+	details.set("a", `{"place_id": "a", "name": "Clinic", "formatted_phone_number": "555 0199", "business_status": "CLOSED_PERMANENTLY"}`)
+	if _, err := m.Refresh(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	details.set("a", "")
+	if _, err := m.Refresh(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Refresh(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "[closed a [] updated a [formatted_phone_number] removed a []]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("changes = %v, want %v", got, want)
+	}
+	if len(ch) != 3 {
+		t.Errorf("channel received %d changes, want 3", len(ch))
+	}
+	if _, ok := m.Last("a"); ok {
+		t.Error("Last() of a removed place is still known")
+	}
+}
+
+func TestMonitorRefreshPermanentlyClosed(t *testing.T) {
+	details := &mutableDetails{results: map[string]string{
+		"a": `{"place_id": "a", "name": "Clinic", "business_status": "CLOSED_TEMPORARILY"}`,
+	}}
+	ts := httptest.NewServer(details)
+	defer ts.Close()
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL))
+
+	ch := make(chan Change, 10)
+	m := service.Monitor("a")
+	m.Sinks = []Sink{ChannelSink(ch)}
+	ctx := context.Background()
+
+	if _, err := m.Refresh(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	details.set("a", `{"place_id": "a", "name": "Clinic", "business_status": "CLOSED_PERMANENTLY"}`)
+	if _, err := m.Refresh(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ch) != 1 {
+		t.Fatalf("channel received %d changes, want 1", len(ch))
+	}
+	if c := <-ch; c.Kind != PlaceClosed || c.Old != ClosedTemporarily || c.New != ClosedPermanently {
+		t.Errorf("change = %+v, want the place closed permanently", c)
+	}
+}
+
+func TestMonitorSinkError(t *testing.T) {
+	details := &mutableDetails{results: map[string]string{"a": `{"place_id": "a", "name": "A"}`}}
+	ts := httptest.NewServer(details)
+	defer ts.Close()
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL))
+
+	sent := 0
+	failed := errors.New("sink failed")
+	m := service.Monitor("a")
+	m.Sinks = []Sink{
+		SinkFunc(func(context.Context, []Change) error { return failed }),
+		SinkFunc(func(context.Context, []Change) error { sent++; return nil }),
+	}
+	m.Refresh(context.Background(), "a")
+	details.set("a", `{"place_id": "a", "name": "B"}`)
+	changes, err := m.Refresh(context.Background(), "a")
+	if err != failed || len(changes) != 1 || sent != 1 {
+		t.Errorf("Refresh() = %v, %v with %d sent, want the change, %v and the other sink called", changes, err, sent, failed)
+	}
+}
+
+func TestMonitorState(t *testing.T) {
+	details := &mutableDetails{results: map[string]string{
+		"a": `{"place_id": "a", "name": "Clinic", "formatted_phone_number": "555 0100"}`,
+		"b": `{"place_id": "b", "name": "Pharmacy", "rating": 0}`,
+	}}
+	ts := httptest.NewServer(details)
+	defer ts.Close()
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL))
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	m := service.Monitor("a", "b")
+	m.StatePath = path
+	for _, id := range m.IDs() {
+		if _, err := m.Refresh(context.Background(), id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	details.set("a", `{"place_id": "a", "name": "Clinic", "formatted_phone_number": "555 0199"}`)
+	restarted := service.Monitor("a", "b")
+	restarted.StatePath = path
+	if err := restarted.Load(); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		ID   string
+		Want string
+	}{
+		{"a", "[{updated a  Clinic <nil> <nil> [formatted_phone_number]}]"},
+		// Fields that were missing before the restart must not appear to have been removed.
+		{"b", "[]"},
+	} {
+		changes, err := restarted.Refresh(context.Background(), test.ID)
+		if err != nil || fmt.Sprint(changes) != test.Want {
+			t.Errorf("Refresh(%q) after restart = %v, %v, want %s", test.ID, changes, err, test.Want)
+		}
+	}
+
+	missing := service.Monitor("a")
+	missing.StatePath = filepath.Join(t.TempDir(), "missing.json")
+	if err := missing.Load(); err != nil {
+		t.Errorf("Load() of a missing file = %v", err)
+	}
+}
+
+func TestMonitorRun(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("placeid")
+		mu.Lock()
+		requests[id]++
+		n := requests[id]
+		mu.Unlock()
+		fmt.Fprintf(w, `{"status": "OK", "result": {"place_id": %q, "name": "%s %d"}}`, id, id, n)
+	}))
+	defer ts.Close()
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL))
+
+	ch := make(chan Change, 10)
+	m := service.Monitor("a", "b")
+	m.Interval = 20 * time.Millisecond
+	m.Sinks = []Sink{ChannelSink(ch)}
+	m.OnError = func(id string, err error) { t.Errorf("refreshing %s: %v", id, err) }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- m.Run(ctx) }()
+
+	seen := map[string]bool{}
+	for len(seen) < 2 {
+		select {
+		case c := <-ch:
+			if c.Kind != PlaceRenamed {
+				t.Errorf("change = %+v, want a rename", c)
+			}
+			seen[c.PlaceID] = true
+		case <-ctx.Done():
+			t.Fatalf("renames seen for %v before timing out", seen)
+		}
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var body struct{ Changes []Change }
+	status := http.StatusNoContent
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		if r.Method != "POST" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("webhook request %s with content type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(status)
+	}))
+	defer ts.Close()
+
+	sink := WebhookSink(http.DefaultClient, ts.URL)
+	changes := []Change{{Kind: PlaceClosed, PlaceID: "a", Name: "Clinic", Old: Operational, New: ClosedPermanently}}
+	if err := sink.Send(context.Background(), changes); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Changes) != 1 || body.Changes[0].Kind != PlaceClosed || body.Changes[0].New != "CLOSED_PERMANENTLY" {
+		t.Errorf("webhook received %+v", body.Changes)
+	}
+
+	status = http.StatusInternalServerError
+	if err := sink.Send(context.Background(), changes); err == nil {
+		t.Error("Send() to a failing webhook succeeded")
+	}
+}
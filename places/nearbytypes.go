@@ -0,0 +1,247 @@
+package places
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+var (
+	errMissingTypes = errors.New("at least one type is required")
+	errStopPages    = errors.New("stop paging")
+)
+
+// NearbyTypes searches around a point for places matching any of the given types. The API honors only one type per request, so one Nearby Search is sent for each type, concurrently, and the results are merged.
+func (p *Service) NearbyTypes(lat, lng float64, types ...FeatureType) *NearbyTypesCall {
+	return &NearbyTypesCall{
+		service:  p,
+		location: NewLocation(lat, lng),
+		types:    types,
+	}
+}
+
+// NearbyTypesCall represents a set of Nearby Search calls, one for each type, whose results are merged.
+type NearbyTypesCall struct {
+	service *Service
+	ctx     context.Context
+
+	// The latitude/longitude around which to retrieve place information
+	location Location
+	// The types to search for. Duplicates are searched once.
+	types []FeatureType
+
+	// A term to be matched against all content that Google has indexed for this place, including but not limited to name, type, and address, as well as customer reviews and other third-party content.
+	Keyword string
+	// The language code, indicating in which language the results should be returned, if possible. Defaults to the service's language.
+	Language string
+	// Restricts results to only those places within the specified price level.
+	MinPrice, MaxPrice *PriceLevel
+	// One or more terms to be matched against the names of places, separated with a space character.
+	Name string
+	// Returns only those places that are open for business at the time the query is sent.
+	OpenNow bool
+	// Defines the distance (in meters) within which to return place results. The maximum allowed radius is 50 000 meters. Note that radius must not be included if rankby=distance is specified.
+	Radius float64
+	// Specifies the order in which each search and the merged results are listed. With RankByDistance the merged results are ordered by their distance from the location; otherwise they are ordered by the best position each place had in any of the searches, so the most prominent place of every type comes before the second of any.
+	RankBy RankBy
+	// Restricts the search to locations that are Zagat selected businesses.
+	ZagatSelected bool
+	// Limits how many pages of results are fetched for each type. Zero fetches every page, of which the API returns at most three.
+	MaxPages int
+}
+
+// NearbyTypesResponse holds the merged results of a NearbyTypesCall.
+type NearbyTypesResponse struct {
+	// The places found by any of the searches, each listed once.
+	Results []NearbyTypesResult
+}
+
+// NearbyTypesResult is a place found by a NearbyTypesCall.
+type NearbyTypesResult struct {
+	PlaceDetails
+	// MatchedTypes lists the requested types whose searches returned the place, in the order the types were requested.
+	MatchedTypes []FeatureType `json:"matched_types,omitempty"`
+	// Distance is the distance in meters from the search location to the place.
+	Distance float64 `json:"distance"`
+
+	// position is the best position the place had in the results of any search.
+	position int
+}
+
+// UnmarshalJSON decodes the place and the fields added by the merged search, which the embedded PlaceDetails would otherwise decode on its own.
+func (r *NearbyTypesResult) UnmarshalJSON(data []byte) error {
+	var extra struct {
+		MatchedTypes []FeatureType `json:"matched_types"`
+		Distance     float64       `json:"distance"`
+	}
+	if err := unmarshalResult(data, &r.PlaceDetails, &extra); err != nil {
+		return err
+	}
+	r.MatchedTypes, r.Distance = extra.MatchedTypes, extra.Distance
+	return nil
+}
+
+// Context sets the context used by Do. Cancelling it aborts every search.
+func (c *NearbyTypesCall) Context(ctx context.Context) *NearbyTypesCall {
+	c.ctx = ctx
+	return c
+}
+
+// calls returns the Nearby Search call for each distinct type, after validating them.
+func (c *NearbyTypesCall) calls() ([]*NearbyCall, error) {
+	var types []FeatureType
+	seen := map[FeatureType]bool{}
+	for _, t := range c.types {
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+	if len(types) == 0 {
+		return nil, ValidationErrors{{Field: "type", Err: errMissingTypes}}
+	}
+
+	var v validator
+	calls := make([]*NearbyCall, len(types))
+	for i, t := range types {
+		calls[i] = &NearbyCall{
+			service:       c.service,
+			location:      c.location,
+			Keyword:       c.Keyword,
+			Language:      c.Language,
+			MinPrice:      c.MinPrice,
+			MaxPrice:      c.MaxPrice,
+			Name:          c.Name,
+			OpenNow:       c.OpenNow,
+			Radius:        c.Radius,
+			RankBy:        c.RankBy,
+			Type:          t,
+			ZagatSelected: c.ZagatSelected,
+		}
+		// The calls differ only by type, so the other parameters are reported once.
+		if i == 0 {
+			if err := calls[i].validate(); err != nil {
+				v.errs = append(v.errs, err.(ValidationErrors)...)
+			}
+		} else {
+			v.featureType(t)
+		}
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return calls, nil
+}
+
+// Do runs the search for every type and merges the results. If any search fails the others are cancelled and the first error is returned. A type with no results does not cause an error.
+func (c *NearbyTypesCall) Do() (*NearbyTypesResponse, error) {
+	calls, err := c.calls()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(callContext(c.ctx))
+	defer cancel()
+
+	lists := make([][]PlaceDetails, len(calls))
+	errs := make([]error, len(calls))
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		wg.Add(1)
+		go func(i int, call *NearbyCall) {
+			defer wg.Done()
+			lists[i], errs[i] = collectPages(ctx, call, c.MaxPages)
+			if errs[i] != nil {
+				cancel()
+			}
+		}(i, call)
+	}
+	wg.Wait()
+
+	if err := firstError(errs); err != nil {
+		return nil, err
+	}
+
+	types := make([]FeatureType, len(calls))
+	for i, call := range calls {
+		types[i] = call.Type
+	}
+	return &NearbyTypesResponse{
+		Results: mergeResults(c.location.LatLng(), types, lists, c.RankBy),
+	}, nil
+}
+
+// collectPages returns the results of every page of call, up to maxPages if it is not zero.
+func collectPages(ctx context.Context, call *NearbyCall, maxPages int) ([]PlaceDetails, error) {
+	var results []PlaceDetails
+	pages := 0
+	err := call.Pages(ctx, func(resp *SearchResponse) error {
+		results = append(results, resp.Results...)
+		pages++
+		if maxPages > 0 && pages >= maxPages {
+			return errStopPages
+		}
+		return nil
+	})
+	if err == errStopPages || IsZeroResults(err) {
+		err = nil
+	}
+	return results, err
+}
+
+// firstError returns the first error that did not come from a search being cancelled after another one failed.
+func firstError(errs []error) error {
+	var canceled error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, context.Canceled) {
+			return err
+		}
+		canceled = err
+	}
+	return canceled
+}
+
+// mergeResults combines the results found for each type, listing every place once.
+func mergeResults(origin LatLng, types []FeatureType, lists [][]PlaceDetails, rankBy RankBy) []NearbyTypesResult {
+	var merged []NearbyTypesResult
+	index := map[string]int{}
+	for i, list := range lists {
+		for position, place := range list {
+			j, ok := index[place.PlaceID]
+			if !ok || place.PlaceID == "" {
+				index[place.PlaceID] = len(merged)
+				merged = append(merged, NearbyTypesResult{
+					PlaceDetails: place,
+					MatchedTypes: []FeatureType{types[i]},
+					Distance:     Distance(origin, place.Geometry.Location),
+					position:     position,
+				})
+				continue
+			}
+
+			result := &merged[j]
+			if result.MatchedTypes[len(result.MatchedTypes)-1] != types[i] {
+				result.MatchedTypes = append(result.MatchedTypes, types[i])
+			}
+			if position < result.position {
+				result.position = position
+			}
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		a, b := merged[i], merged[j]
+		if rankBy == RankByDistance && a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		if a.position != b.position {
+			return a.position < b.position
+		}
+		return len(a.MatchedTypes) > len(b.MatchedTypes)
+	})
+	return merged
+}
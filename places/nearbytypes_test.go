@@ -0,0 +1,216 @@
+package places
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// nearbyTypesHandler serves Nearby Search results keyed by type, or by page token for later pages since their requests carry no other parameters.
+func nearbyTypesHandler(pages map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		body, ok := pages[q.Get("type")+q.Get("pagetoken")]
+		if !ok {
+			body = `{"status": "ZERO_RESULTS"}`
+		}
+		fmt.Fprint(w, body)
+	}
+}
+
+func place(id string, lat, lng float64) string {
+	return fmt.Sprintf(`{"place_id": %q, "name": %q, "geometry": {"location": {"lat": %v, "lng": %v}}}`, id, id, lat, lng)
+}
+
+func TestNearbyTypesCallDo(t *testing.T) {
+	defer func(d time.Duration) { pageTokenDelay = d }(pageTokenDelay)
+	pageTokenDelay = time.Millisecond
+
+	ts := httptest.NewServer(nearbyTypesHandler(map[string]string{
+		"cafe":       `{"status": "OK", "results": [` + place("espresso", 0, 0.01) + `,` + place("both", 0, 0.001) + `], "next_page_token": "more"}`,
+		"more":       `{"status": "OK", "results": [` + place("late", 0, 0.0001) + `]}`,
+		"bakery":     `{"status": "OK", "results": [` + place("both", 0, 0.001) + `,` + place("bread", 0, 0.02) + `]}`,
+		"book_store": `{"status": "OK", "results": [` + place("books", 0, 0.03) + `]}`,
+	}))
+	defer ts.Close()
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL))
+
+	for _, test := range []struct {
+		Name     string
+		Types    []FeatureType
+		RankBy   RankBy
+		MaxPages int
+		Want     string
+	}{
+		{
+			Name:  "prominence",
+			Types: []FeatureType{Cafe, Bakery},
+			Want:  "[both:[cafe bakery] espresso:[cafe] bread:[bakery] late:[cafe]]",
+		},
+		{
+			Name:   "distance",
+			Types:  []FeatureType{Cafe, Bakery, Cafe},
+			RankBy: RankByDistance,
+			Want:   "[late:[cafe] both:[cafe bakery] espresso:[cafe] bread:[bakery]]",
+		},
+		{
+			Name:     "one page per type",
+			Types:    []FeatureType{Bakery, Cafe},
+			MaxPages: 1,
+			Want:     "[both:[bakery cafe] espresso:[cafe] bread:[bakery]]",
+		},
+		{
+			Name:  "type without results",
+			Types: []FeatureType{BookStore, Zoo},
+			Want:  "[books:[book_store]]",
+		},
+	} {
+		call := service.NearbyTypes(0, 0, test.Types...)
+		call.RankBy = test.RankBy
+		call.MaxPages = test.MaxPages
+		if test.RankBy != RankByDistance {
+			call.Radius = 5000
+		}
+
+		resp, err := call.Do()
+		if err != nil {
+			t.Errorf("%s: NearbyTypesCall{}.Do() = %v", test.Name, err)
+			continue
+		}
+		var got []string
+		for _, r := range resp.Results {
+			got = append(got, fmt.Sprintf("%s:%v", r.PlaceID, r.MatchedTypes))
+		}
+		if fmt.Sprint(got) != test.Want {
+			t.Errorf("%s: NearbyTypesCall{}.Do() = %v, want %v", test.Name, got, test.Want)
+		}
+	}
+}
+
+func TestNearbyTypesCallDistance(t *testing.T) {
+	ts := httptest.NewServer(nearbyTypesHandler(map[string]string{
+		"cafe": `{"status": "OK", "results": [` + place("here", -33.8670522, 151.1957362) + `,` + place("there", -33.8570522, 151.1957362) + `]}`,
+	}))
+	defer ts.Close()
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL))
+
+	call := service.NearbyTypes(-33.8670522, 151.1957362, Cafe)
+	call.Radius = 2000
+	resp, err := call.Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := resp.Results[0].Distance; d != 0 {
+		t.Errorf("distance to the search location = %v, want 0", d)
+	}
+	if d := resp.Results[1].Distance; d < 1110 || d > 1113 {
+		t.Errorf("distance 0.01 degrees north = %v, want about 1112", d)
+	}
+}
+
+func TestNearbyTypesCallError(t *testing.T) {
+	var mu sync.Mutex
+	var types []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		types = append(types, r.URL.Query().Get("type"))
+		mu.Unlock()
+		if r.URL.Query().Get("type") == "restaurant" {
+			fmt.Fprint(w, `{"status": "REQUEST_DENIED"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status": "OK", "results": []}`)
+	}))
+	defer ts.Close()
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL))
+
+	call := service.NearbyTypes(0, 0, Cafe, Restaurant, Bakery)
+	call.Radius = 500
+	if _, err := call.Do(); !IsRequestDenied(err) {
+		t.Errorf("NearbyTypesCall{}.Do() = %v, want REQUEST_DENIED", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	call = service.NearbyTypes(0, 0, Cafe, Bakery)
+	call.Radius = 500
+	if _, err := call.Context(ctx).Do(); !errors.Is(err, context.Canceled) {
+		t.Errorf("NearbyTypesCall{}.Do() with cancelled context = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestNearbyTypesCallValidate(t *testing.T) {
+	for _, test := range []struct {
+		Name   string
+		Call   *NearbyTypesCall
+		Want   error
+		Fields string
+	}{
+		{
+			Name:   "no types",
+			Call:   dummyService.NearbyTypes(0, 0),
+			Want:   errMissingTypes,
+			Fields: "type",
+		},
+		{
+			Name:   "missing radius and bad type",
+			Call:   dummyService.NearbyTypes(0, 0, Cafe, Locality),
+			Want:   errResponseOnlyType,
+			Fields: "radius type",
+		},
+		{
+			Name:   "radius too great",
+			Call:   &NearbyTypesCall{types: []FeatureType{Cafe, Bar}, Radius: maximumRadius + 1},
+			Want:   errRadiusIsTooGreat,
+			Fields: "radius",
+		},
+	} {
+		_, err := test.Call.calls()
+		if !errors.Is(err, test.Want) {
+			t.Errorf("%s: NearbyTypesCall{}.calls() = %v, want %v", test.Name, err, test.Want)
+			continue
+		}
+		var fields []string
+		for _, e := range err.(ValidationErrors) {
+			fields = append(fields, e.Field)
+		}
+		if got := strings.Join(fields, " "); got != test.Fields {
+			t.Errorf("%s: invalid fields = %q, want %q", test.Name, got, test.Fields)
+		}
+	}
+}
+
+func TestNearbyTypesResultJSON(t *testing.T) {
+	in := NearbyTypesResult{
+		PlaceDetails: PlaceDetails{PlaceID: "a", Name: "A"},
+		MatchedTypes: []FeatureType{Bar, NightClub},
+		Distance:     87.5,
+	}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out NearbyTypesResult
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.PlaceID != in.PlaceID || out.Name != in.Name {
+		t.Errorf("place = %+v, want %+v", out.PlaceDetails, in.PlaceDetails)
+	}
+	if !reflect.DeepEqual(out.MatchedTypes, in.MatchedTypes) || out.Distance != in.Distance {
+		t.Errorf("got %v, %v, want %v, %v", out.MatchedTypes, out.Distance, in.MatchedTypes, in.Distance)
+	}
+	for _, key := range []string{"matched_types", "distance"} {
+		if out.present[key] {
+			t.Errorf("%s recorded as a place field", key)
+		}
+	}
+}
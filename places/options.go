@@ -0,0 +1,123 @@
+package places
+
+import (
+	"log/slog"
+	"time"
+)
+
+// An Option configures a Service created by NewService.
+type Option func(*Service)
+
+// WithBaseURL sends requests to url instead of https://maps.googleapis.com/maps/api/place, e.g. to use a proxy or a fake server in tests.
+func WithBaseURL(url string) Option {
+	return func(s *Service) {
+		s.url = url
+	}
+}
+
+// WithLanguage sets the language code used by calls that do not set their own Language.
+func WithLanguage(language string) Option {
+	return func(s *Service) {
+		s.language = language
+	}
+}
+
+// WithRegion sets the region code, a ccTLD such as "uk", used to bias Text Search and Details results.
+func WithRegion(region string) Option {
+	return func(s *Service) {
+		s.region = region
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) Option {
+	return func(s *Service) {
+		s.userAgent = userAgent
+	}
+}
+
+// WithTimeout limits how long a call may take, including any retries. It applies on top of any deadline set on the call's context.
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *Service) {
+		s.timeout = timeout
+	}
+}
+
+// WithLimiter makes every request wait for l before it is sent.
+func WithLimiter(l Limiter) Option {
+	return func(s *Service) {
+		s.limiter = l
+	}
+}
+
+// WithCache makes the service answer repeated calls from c instead of the API. Only successful responses are cached.
+func WithCache(c Cache) Option {
+	return func(s *Service) {
+		s.cache = c
+	}
+}
+
+// WithRetryPolicy makes the service retry calls that fail with a transient error, as described by policy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(s *Service) {
+		s.retry = &policy
+	}
+}
+
+// WithKeyPool makes the service draw its API key from pool for every request instead of using the key passed to NewService. Requests that fail with OVER_QUERY_LIMIT or REQUEST_DENIED are retried with the next available key in the pool.
+func WithKeyPool(pool *KeyPool) Option {
+	return func(s *Service) {
+		s.keys = pool
+	}
+}
+
+// WithClientCredentials makes the service authenticate with a client ID and URL signature instead of an API key. Client credentials take precedence over the API key and any KeyPool.
+func WithClientCredentials(creds *ClientCredentials) Option {
+	return func(s *Service) {
+		s.creds = creds
+	}
+}
+
+// WithCircuitBreaker wraps every request the service makes in the given circuit breaker.
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(s *Service) {
+		s.breaker = breaker
+	}
+}
+
+// WithCollector makes the service report every request to c.
+func WithCollector(c Collector) Option {
+	return func(s *Service) {
+		s.collector = c
+	}
+}
+
+// WithLogger makes the service log every request it sends to logger. Successful requests, including those returning ZERO_RESULTS, are logged at slog.LevelDebug and failed ones at slog.LevelWarn unless changed with WithLogLevels.
+//
+// The logged parameters are those of the call, which never include the key, client ID or signature.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Service) {
+		s.logger = logger
+	}
+}
+
+// WithLogLevels sets the levels at which successful and failed requests are logged.
+func WithLogLevels(ok, failed slog.Level) Option {
+	return func(s *Service) {
+		s.logLevels = &logLevels{ok: ok, failed: failed}
+	}
+}
+
+// WithTracer makes the service start spans with t.
+func WithTracer(t Tracer) Option {
+	return func(s *Service) {
+		s.tracer = t
+	}
+}
+
+// WithDryRun makes the service record requests in d instead of sending them. Nothing else about the request is observed: the cache, limiter, circuit breaker, retry policy, collector, logger and tracer are all bypassed.
+func WithDryRun(d *DryRun) Option {
+	return func(s *Service) {
+		s.dryRun = d
+	}
+}
@@ -0,0 +1,94 @@
+package places
+
+import (
+	"context"
+	"time"
+)
+
+// pageTokenDelay is how long to wait before requesting the next page. A next_page_token only becomes valid a short time after it is issued.
+var pageTokenDelay = 2 * time.Second
+
+// maxPageTokenRetries is how many more times a page is requested if the API rejects its token as not yet valid.
+const maxPageTokenRetries = 3
+
+// Pages calls f with each page of results in turn, following next_page_token until there are no more pages, f returns an error or ctx is done. The call's PageToken is used for the first page and is left unchanged.
+func (n *NearbyCall) Pages(ctx context.Context, f func(*SearchResponse) error) error {
+	token := n.PageToken
+	defer func() { n.PageToken = token }()
+
+	return n.service.pages(ctx, "nearbysearch", token, func(ctx context.Context, pageToken string) (*SearchResponse, error) {
+		n.PageToken = pageToken
+		return n.do(ctx)
+	}, f)
+}
+
+// Pages calls f with each page of results in turn, following next_page_token until there are no more pages, f returns an error or ctx is done. The call's PageToken is used for the first page and is left unchanged.
+func (t *TextSearchCall) Pages(ctx context.Context, f func(*SearchResponse) error) error {
+	token := t.PageToken
+	defer func() { t.PageToken = token }()
+
+	return t.service.pages(ctx, "textsearch", token, func(ctx context.Context, pageToken string) (*SearchResponse, error) {
+		t.PageToken = pageToken
+		return t.do(ctx)
+	}, f)
+}
+
+// pages drives the pagination for Pages, tracing each page with its index.
+func (s *Service) pages(ctx context.Context, endpoint, token string, fetch func(context.Context, string) (*SearchResponse, error), f func(*SearchResponse) error) error {
+	for page := 0; ; page++ {
+		pageCtx, span := s.startSpan(ctx, "places.page",
+			Attr("places.endpoint", endpoint),
+			Attr("places.page_index", page),
+		)
+
+		var resp *SearchResponse
+		var err error
+		if page == 0 {
+			resp, err = fetch(pageCtx, token)
+		} else {
+			resp, err = fetchNextPage(pageCtx, token, fetch)
+		}
+		if err == nil {
+			span.SetAttributes(
+				Attr("places.result_count", len(resp.Results)),
+				Attr("places.next_page_token", resp.NextPageToken != ""),
+			)
+			err = f(resp)
+		}
+		span.End(err)
+
+		if err != nil {
+			return err
+		}
+		if resp.NextPageToken == "" {
+			return nil
+		}
+		token = resp.NextPageToken
+	}
+}
+
+// fetchNextPage waits for token to become valid before fetching the page, retrying while the API still reports it as invalid.
+func fetchNextPage(ctx context.Context, token string, fetch func(context.Context, string) (*SearchResponse, error)) (*SearchResponse, error) {
+	for retry := 0; ; retry++ {
+		if err := sleep(ctx, pageTokenDelay); err != nil {
+			return nil, err
+		}
+		resp, err := fetch(ctx, token)
+		if IsInvalidRequest(err) && retry < maxPageTokenRetries {
+			continue
+		}
+		return resp, err
+	}
+}
+
+// sleep waits for d, returning early with the context's error if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
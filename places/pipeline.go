@@ -0,0 +1,258 @@
+package places
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Filter reports whether a place should be kept by a Pipeline.
+type Filter func(p *PlaceDetails) bool
+
+// A Sorter reports whether place a should be listed before place b.
+type Sorter func(a, b *PlaceDetails) bool
+
+// Pipeline filters and orders search results on the client, e.g. to rank them by rating, which the API cannot do. The zero value keeps every place in its original order.
+//
+//	p := places.NewPipeline().
+//		Filter(places.MinRating(4), places.HasPhotos()).
+//		SortBy(places.ByBayesianRating(3.5, 10), places.ByName())
+//	p.ApplyTo(resp)
+type Pipeline struct {
+	filters []Filter
+	sorters []Sorter
+}
+
+// NewPipeline returns an empty Pipeline.
+func NewPipeline() *Pipeline {
+	return &Pipeline{}
+}
+
+// Filter adds filters to the pipeline. A place is kept only if every filter keeps it.
+func (p *Pipeline) Filter(filters ...Filter) *Pipeline {
+	p.filters = append(p.filters, filters...)
+	return p
+}
+
+// SortBy adds sorters to the pipeline. Each sorter only orders the places that all earlier sorters consider equal, and places that every sorter considers equal keep their original order.
+func (p *Pipeline) SortBy(sorters ...Sorter) *Pipeline {
+	p.sorters = append(p.sorters, sorters...)
+	return p
+}
+
+// Apply returns the places kept by the filters, in the order given by the sorters. places is not modified.
+func (p *Pipeline) Apply(places []PlaceDetails) []PlaceDetails {
+	var kept []PlaceDetails
+	for i := range places {
+		if p.keep(&places[i]) {
+			kept = append(kept, places[i])
+		}
+	}
+
+	if len(p.sorters) > 0 {
+		sort.SliceStable(kept, func(i, j int) bool {
+			a, b := &kept[i], &kept[j]
+			for _, less := range p.sorters {
+				if less(a, b) {
+					return true
+				}
+				if less(b, a) {
+					return false
+				}
+			}
+			return false
+		})
+	}
+	return kept
+}
+
+// ApplyTo replaces the results of resp with those returned by Apply.
+func (p *Pipeline) ApplyTo(resp *SearchResponse) {
+	resp.Results = p.Apply(resp.Results)
+}
+
+func (p *Pipeline) keep(place *PlaceDetails) bool {
+	for _, f := range p.filters {
+		if !f(place) {
+			return false
+		}
+	}
+	return true
+}
+
+// MinRating keeps places rated at least rating. Unrated places are dropped.
+func MinRating(rating float64) Filter {
+	return func(p *PlaceDetails) bool {
+		return p.Rating > 0 && p.Rating >= rating
+	}
+}
+
+// PriceRange keeps places whose price level is between min and max, inclusive. Places without a price level are dropped.
+func PriceRange(min, max PriceLevel) Filter {
+	return func(p *PlaceDetails) bool {
+		return p.PriceLevel != nil && *p.PriceLevel >= min && *p.PriceLevel <= max
+	}
+}
+
+// OpenAt keeps places that are open at t, according to their opening periods. Places without opening periods are dropped.
+func OpenAt(t time.Time) Filter {
+	return func(p *PlaceDetails) bool {
+		return p.OpenAt(t)
+	}
+}
+
+// HasPhotos keeps places with at least one photo.
+func HasPhotos() Filter {
+	return func(p *PlaceDetails) bool {
+		return len(p.Photos) > 0
+	}
+}
+
+// IncludeTypes keeps places that have at least one of types.
+func IncludeTypes(types ...FeatureType) Filter {
+	return func(p *PlaceDetails) bool {
+		return p.hasAnyType(types)
+	}
+}
+
+// ExcludeTypes drops places that have any of types.
+func ExcludeTypes(types ...FeatureType) Filter {
+	return func(p *PlaceDetails) bool {
+		return !p.hasAnyType(types)
+	}
+}
+
+// WithinPolygon keeps places inside the polygon with the given vertices. The polygon is closed automatically and must not cross the antimeridian.
+func WithinPolygon(polygon []LatLng) Filter {
+	return func(p *PlaceDetails) bool {
+		return insidePolygon(p.Geometry.Location, polygon)
+	}
+}
+
+// ByDistance lists the places nearest to from first.
+func ByDistance(from LatLng) Sorter {
+	return func(a, b *PlaceDetails) bool {
+		return Distance(from, a.Geometry.Location) < Distance(from, b.Geometry.Location)
+	}
+}
+
+// ByRating lists the highest rated places first.
+func ByRating() Sorter {
+	return func(a, b *PlaceDetails) bool {
+		return a.Rating > b.Rating
+	}
+}
+
+// ByBayesianRating lists the places with the highest Bayesian average rating first. Each rating is weighted by the number of reviews behind it, pulling ratings with few reviews towards mean as if the place also had confidence reviews of mean, so a 4.8 from five reviews does not outrank a 4.6 from five hundred.
+func ByBayesianRating(mean, confidence float64) Sorter {
+	return func(a, b *PlaceDetails) bool {
+		return bayesianRating(a, mean, confidence) > bayesianRating(b, mean, confidence)
+	}
+}
+
+// ByName lists places in alphabetical order of their name, ignoring case.
+func ByName() Sorter {
+	return func(a, b *PlaceDetails) bool {
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	}
+}
+
+// Reverse lists places in the opposite order to s.
+func Reverse(s Sorter) Sorter {
+	return func(a, b *PlaceDetails) bool {
+		return s(b, a)
+	}
+}
+
+func bayesianRating(p *PlaceDetails, mean, confidence float64) float64 {
+	if p.Rating == 0 {
+		return mean
+	}
+	n := float64(p.reviewCount())
+	return (confidence*mean + n*p.Rating) / (confidence + n)
+}
+
+// reviewCount returns the number of reviews the place's rating is based on, or the number of reviews included in the result if the API did not report the total.
+func (p *PlaceDetails) reviewCount() int {
+	if p.UserRatingsTotal > 0 {
+		return p.UserRatingsTotal
+	}
+	return len(p.Reviews)
+}
+
+// utcOffset returns the offset of the place's time zone from UTC in minutes.
+func (p *PlaceDetails) utcOffset() int {
+	if p.UTCOffsetMinutes != 0 {
+		return p.UTCOffsetMinutes
+	}
+	return p.UTCOffset
+}
+
+func (p *PlaceDetails) hasAnyType(types []FeatureType) bool {
+	for _, have := range p.Types {
+		for _, want := range types {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// minutesPerWeek is the length of the week that opening periods repeat over.
+const minutesPerWeek = 7 * 24 * 60
+
+// OpenAt reports whether the place is open at t according to its opening periods, which are in the place's local time given by UTCOffsetMinutes. It returns false if the place has no opening periods.
+func (p *PlaceDetails) OpenAt(t time.Time) bool {
+	local := t.UTC().Add(time.Duration(p.utcOffset()) * time.Minute)
+	now := int(local.Weekday())*24*60 + local.Hour()*60 + local.Minute()
+
+	for _, period := range p.OpeningHours.Periods {
+		opens, ok := period.Open.weekMinute()
+		if !ok {
+			continue
+		}
+		if period.Close.Time == "" {
+			// A period without a close time means the place is always open.
+			return true
+		}
+		closes, ok := period.Close.weekMinute()
+		if !ok {
+			continue
+		}
+		if opens <= closes && now >= opens && now < closes {
+			return true
+		}
+		// The period wraps around from Saturday to Sunday.
+		if opens > closes && (now >= opens || now < closes) {
+			return true
+		}
+	}
+	return false
+}
+
+// weekMinute returns the minute of the week, counted from midnight on Sunday, at which d falls.
+func (d DayTime) weekMinute() (int, bool) {
+	if len(d.Time) != 4 || d.Day < 0 || d.Day > 6 {
+		return 0, false
+	}
+	hhmm, err := strconv.Atoi(d.Time)
+	if err != nil || hhmm%100 > 59 || hhmm > 2400 {
+		return 0, false
+	}
+	return (d.Day*24*60 + hhmm/100*60 + hhmm%100) % minutesPerWeek, true
+}
+
+// insidePolygon reports whether point lies inside polygon, treating coordinates as planar, using the even-odd rule.
+func insidePolygon(point LatLng, polygon []LatLng) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Lat > point.Lat) != (b.Lat > point.Lat) &&
+			point.Lng < (b.Lng-a.Lng)*(point.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			inside = !inside
+		}
+	}
+	return inside
+}
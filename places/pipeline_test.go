@@ -0,0 +1,133 @@
+package places
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+var pipelinePlaces = []PlaceDetails{
+	{
+		Name:       "Zephyr Cafe",
+		Rating:     4.8,
+		Reviews:    []*Review{{Rating: 5}},
+		PriceLevel: priceLevel(Inexpensive),
+		Types:      []FeatureType{Cafe, Food, Establishment},
+		Geometry:   Geometry{Location: LatLng{Lat: 0.5, Lng: 0.5}},
+		Photos:     []Photo{{PhotoReference: "a"}},
+	},
+	{
+		Name:       "anchor bar",
+		Rating:     4.5,
+		Reviews:    []*Review{{Rating: 4}, {Rating: 5}, {Rating: 4}, {Rating: 5}, {Rating: 5}},
+		PriceLevel: priceLevel(Expensive),
+		Types:      []FeatureType{Bar, Establishment},
+		Geometry:   Geometry{Location: LatLng{Lat: 0.1, Lng: 0.1}},
+	},
+	{
+		Name:     "Museum of Maps",
+		Types:    []FeatureType{Museum, Establishment},
+		Geometry: Geometry{Location: LatLng{Lat: 2, Lng: 2}},
+		Photos:   []Photo{{PhotoReference: "b"}},
+	},
+	{
+		Name:       "Bakery",
+		Rating:     4.5,
+		Reviews:    []*Review{{Rating: 4}, {Rating: 5}},
+		PriceLevel: priceLevel(Moderate),
+		Types:      []FeatureType{Bakery, Food, Establishment},
+		Geometry:   Geometry{Location: LatLng{Lat: -0.2, Lng: 0.3}},
+	},
+}
+
+func names(places []PlaceDetails) string {
+	var names []string
+	for _, p := range places {
+		names = append(names, p.Name)
+	}
+	return fmt.Sprintf("%q", names)
+}
+
+func TestPipelineApply(t *testing.T) {
+	square := []LatLng{{0, 0}, {0, 1}, {1, 1}, {1, 0}}
+
+	for _, test := range []struct {
+		Name     string
+		Pipeline *Pipeline
+		Want     string
+	}{
+		{"empty", NewPipeline(), `["Zephyr Cafe" "anchor bar" "Museum of Maps" "Bakery"]`},
+		{"min rating", NewPipeline().Filter(MinRating(4.6)), `["Zephyr Cafe"]`},
+		{"price range", NewPipeline().Filter(PriceRange(Inexpensive, Moderate)), `["Zephyr Cafe" "Bakery"]`},
+		{"has photos", NewPipeline().Filter(HasPhotos()), `["Zephyr Cafe" "Museum of Maps"]`},
+		{"include types", NewPipeline().Filter(IncludeTypes(Food, Museum)), `["Zephyr Cafe" "Museum of Maps" "Bakery"]`},
+		{"exclude types", NewPipeline().Filter(ExcludeTypes(Food)), `["anchor bar" "Museum of Maps"]`},
+		{"within polygon", NewPipeline().Filter(WithinPolygon(square)), `["Zephyr Cafe" "anchor bar"]`},
+		{"combined filters", NewPipeline().Filter(HasPhotos(), IncludeTypes(Cafe)), `["Zephyr Cafe"]`},
+		{"by name", NewPipeline().SortBy(ByName()), `["anchor bar" "Bakery" "Museum of Maps" "Zephyr Cafe"]`},
+		{"by distance", NewPipeline().SortBy(ByDistance(LatLng{})), `["anchor bar" "Bakery" "Zephyr Cafe" "Museum of Maps"]`},
+		{"by rating keeps ties in order", NewPipeline().SortBy(ByRating()), `["Zephyr Cafe" "anchor bar" "Bakery" "Museum of Maps"]`},
+		{"by rating then name", NewPipeline().SortBy(ByRating(), Reverse(ByName())), `["Zephyr Cafe" "Bakery" "anchor bar" "Museum of Maps"]`},
+		{"by bayesian rating", NewPipeline().SortBy(ByBayesianRating(4, 2)), `["anchor bar" "Zephyr Cafe" "Bakery" "Museum of Maps"]`},
+		{"filter then sort", NewPipeline().Filter(IncludeTypes(Food)).SortBy(ByName()), `["Bakery" "Zephyr Cafe"]`},
+	} {
+		if got := names(test.Pipeline.Apply(pipelinePlaces)); got != test.Want {
+			t.Errorf("Pipeline{%s}.Apply() = %s, want %s", test.Name, got, test.Want)
+		}
+	}
+
+	if got := names(pipelinePlaces); got != `["Zephyr Cafe" "anchor bar" "Museum of Maps" "Bakery"]` {
+		t.Errorf("Pipeline{}.Apply() modified its input: %s", got)
+	}
+
+	resp := &SearchResponse{Results: pipelinePlaces}
+	NewPipeline().Filter(MinRating(4.6)).ApplyTo(resp)
+	if got := names(resp.Results); got != `["Zephyr Cafe"]` {
+		t.Errorf("Pipeline{}.ApplyTo() results = %s", got)
+	}
+}
+
+func TestPlaceDetailsOpenAt(t *testing.T) {
+	weekdays := OpeningHours{Periods: []Period{
+		{Open: DayTime{Day: 1, Time: "0900"}, Close: DayTime{Day: 1, Time: "1700"}},
+		{Open: DayTime{Day: 5, Time: "2200"}, Close: DayTime{Day: 6, Time: "0200"}},
+		{Open: DayTime{Day: 6, Time: "2300"}, Close: DayTime{Day: 0, Time: "0300"}},
+	}}
+	always := OpeningHours{Periods: []Period{{Open: DayTime{Day: 0, Time: "0000"}}}}
+
+	// 2026-10-19 is a Monday.
+	monday := func(hour, min int) time.Time { return time.Date(2026, 10, 19, hour, min, 0, 0, time.UTC) }
+
+	for _, test := range []struct {
+		Name   string
+		Hours  OpeningHours
+		Offset int
+		Time   time.Time
+		Want   bool
+	}{
+		{"during opening hours", weekdays, 0, monday(12, 0), true},
+		{"at opening", weekdays, 0, monday(9, 0), true},
+		{"at closing", weekdays, 0, monday(17, 0), false},
+		{"before opening", weekdays, 0, monday(8, 59), false},
+		{"local time ahead of UTC", weekdays, 120, monday(7, 30), true},
+		{"local time behind UTC", weekdays, -300, monday(15, 0), true},
+		{"closed in local time", weekdays, -300, monday(13, 0), false},
+		{"after midnight on Saturday", weekdays, 0, monday(1, 0).AddDate(0, 0, 5), true},
+		{"across the end of the week", weekdays, 0, monday(2, 0).AddDate(0, 0, -1), true},
+		{"always open", always, 0, monday(3, 0), true},
+		{"no periods", OpeningHours{}, 0, monday(12, 0), false},
+	} {
+		p := PlaceDetails{OpeningHours: test.Hours, UTCOffset: test.Offset}
+		if got := p.OpenAt(test.Time); got != test.Want {
+			t.Errorf("PlaceDetails{%s}.OpenAt(%v) = %v, want %v", test.Name, test.Time, got, test.Want)
+		}
+	}
+
+	kept := NewPipeline().Filter(OpenAt(monday(12, 0))).Apply([]PlaceDetails{
+		{Name: "open", OpeningHours: weekdays},
+		{Name: "unknown"},
+	})
+	if got := names(kept); got != `["open"]` {
+		t.Errorf("OpenAt filter kept %s", got)
+	}
+}
@@ -0,0 +1,178 @@
+package places
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+var (
+	errMissingPlaceID = errors.New("a place ID is required")
+	errInvalidPlaceID = errors.New("a place ID may only contain letters, digits, '-' and '_'")
+)
+
+// maxPlaceIDLength is the longest place ID accepted. Google does not limit their length, but IDs for addresses are the longest seen in practice and are well under this.
+const maxPlaceIDLength = 1024
+
+// The scopes of a place ID.
+const (
+	// ScopeGoogle place IDs are recognised by other applications and on Google Maps.
+	ScopeGoogle = "GOOGLE"
+	// ScopeApp place IDs are recognised only by the application that added the place.
+	ScopeApp = "APP"
+)
+
+// IsValidPlaceID returns true if id is syntactically a place ID. Place IDs are URL-safe base64 strings, e.g. "ChIJN1t_tDeuEmsRUsoyG83frY4"; this does not check that the place exists.
+func IsValidPlaceID(id string) bool {
+	return validatePlaceID(id) == nil
+}
+
+func validatePlaceID(id string) error {
+	if id == "" {
+		return errMissingPlaceID
+	}
+	if len(id) > maxPlaceIDLength {
+		return errTextTooLong
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '_') {
+			return errInvalidPlaceID
+		}
+	}
+	return nil
+}
+
+// IsAppScoped returns true if the place's ID is only recognised by the application that added it.
+func (p *PlaceDetails) IsAppScoped() bool {
+	return p.Scope == ScopeApp
+}
+
+// GooglePlaceID returns the Google-scoped ID of the place, which is its PlaceID unless the place was added by an application and has not been moderated yet. Results without a scope are Google-scoped.
+func (p *PlaceDetails) GooglePlaceID() (string, bool) {
+	if p.PlaceID != "" && !p.IsAppScoped() {
+		return p.PlaceID, true
+	}
+	for _, alt := range p.AltIDs {
+		if alt.Scope == ScopeGoogle {
+			return alt.PlaceID, true
+		}
+	}
+	return "", false
+}
+
+// AppPlaceIDs returns the application-scoped IDs of the place: its PlaceID while it awaits moderation, and any alternative IDs it was known by before.
+func (p *PlaceDetails) AppPlaceIDs() []string {
+	var ids []string
+	if p.IsAppScoped() && p.PlaceID != "" {
+		ids = append(ids, p.PlaceID)
+	}
+	for _, alt := range p.AltIDs {
+		if alt.Scope == ScopeApp {
+			ids = append(ids, alt.PlaceID)
+		}
+	}
+	return ids
+}
+
+// mapsURL is the base of Google Maps URLs, documented at https://developers.google.com/maps/documentation/urls/get-started.
+const mapsURL = "https://www.google.com/maps"
+
+// TravelMode is the method of travel for directions.
+type TravelMode string
+
+const (
+	// TravelModeDefault lets Google Maps choose the most relevant mode.
+	TravelModeDefault TravelMode = ""
+	Driving           TravelMode = "driving"
+	Walking           TravelMode = "walking"
+	Bicycling         TravelMode = "bicycling"
+	Transit           TravelMode = "transit"
+)
+
+// MapsSearchURL returns a Google Maps URL that searches for query, e.g. "pizza in Seattle" or "47.5951518,-122.3316393". If placeID is set the place is shown directly and query is only used as its label, and as a fallback if the ID is not recognised.
+func MapsSearchURL(query, placeID string) string {
+	params := url.Values{"api": {"1"}, "query": {query}}
+	if placeID != "" {
+		params.Set("query_place_id", placeID)
+	}
+	return mapsURL + "/search/?" + params.Encode()
+}
+
+// MapsPlaceURL returns a Google Maps URL that shows the place with the given ID.
+func MapsPlaceURL(placeID string) string {
+	return mapsURL + "/place/?q=place_id:" + url.QueryEscape(placeID)
+}
+
+// Directions describes a Google Maps URL that shows directions between two places.
+type Directions struct {
+	// The starting point, as an address, name or comma-separated latitude/longitude. Empty means the user's current location.
+	Origin string
+	// The place ID of the starting point. Origin must also be set.
+	OriginPlaceID string
+	// The end point, as an address, name or comma-separated latitude/longitude.
+	Destination string
+	// The place ID of the end point. Destination must also be set.
+	DestinationPlaceID string
+	// The method of travel.
+	TravelMode TravelMode
+}
+
+// URL returns the Google Maps URL for the directions.
+func (d Directions) URL() string {
+	params := url.Values{"api": {"1"}}
+	for _, p := range []struct{ key, value string }{
+		{"origin", d.Origin},
+		{"origin_place_id", d.OriginPlaceID},
+		{"destination", d.Destination},
+		{"destination_place_id", d.DestinationPlaceID},
+		{"travelmode", string(d.TravelMode)},
+	} {
+		if p.value != "" {
+			params.Set(p.key, p.value)
+		}
+	}
+	return mapsURL + "/dir/?" + params.Encode()
+}
+
+// MapsURL returns a link to the place on Google Maps: the URL from a Details response if there is one, otherwise a search for the place by its ID.
+func (p *PlaceDetails) MapsURL() string {
+	if p.URL != "" {
+		return p.URL
+	}
+	return p.SearchURL()
+}
+
+// SearchURL returns a Google Maps URL that searches for the place by its Google-scoped ID, labelled with its name and address.
+func (p *PlaceDetails) SearchURL() string {
+	id, _ := p.GooglePlaceID()
+	return MapsSearchURL(p.mapsQuery(), id)
+}
+
+// DirectionsURL returns a Google Maps URL with directions to the place from the user's current location.
+func (p *PlaceDetails) DirectionsURL(mode TravelMode) string {
+	id, _ := p.GooglePlaceID()
+	return Directions{
+		Destination:        p.mapsQuery(),
+		DestinationPlaceID: id,
+		TravelMode:         mode,
+	}.URL()
+}
+
+// mapsQuery describes the place for a Maps URL, by name and address if they are known and by its coordinates otherwise.
+func (p *PlaceDetails) mapsQuery() string {
+	address := p.FormattedAddress
+	if address == "" {
+		address = p.Vicinity
+	}
+	parts := make([]string, 0, 2)
+	for _, s := range []string{p.Name, address} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	if len(parts) > 0 {
+		return strings.Join(parts, ", ")
+	}
+	return NewLocation(p.Geometry.Location.Lat, p.Geometry.Location.Lng).String()
+}
@@ -0,0 +1,114 @@
+package places
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestValidatePlaceID(t *testing.T) {
+	for _, test := range []struct {
+		ID   string
+		Want error
+	}{
+		{"ChIJN1t_tDeuEmsRUsoyG83frY4", nil},
+		{"EicxMyBNYXJrZXQgU3QsIFdpbG1pbmd0b24sIE5DLCBVU0EiMBIuChQKEgnRTo6ixx-qiRHo_bbmkCm7ZRIUChIJgwiULPYcqokRF1xY7rsp8g4", nil},
+		{"GhIJQWDl0CIeQUARxks3icF8U8A", nil},
+		{"", errMissingPlaceID},
+		{"ChIJN1t tDeuEmsRUsoyG83frY4", errInvalidPlaceID},
+		{"ChIJN1t+tDeuEmsRUsoyG83frY4=", errInvalidPlaceID},
+		{"ChIJN1t_tDeuEmsRUsoyG83frY4\n", errInvalidPlaceID},
+		{string(make([]byte, maxPlaceIDLength+1)), errTextTooLong},
+	} {
+		if got := validatePlaceID(test.ID); got != test.Want {
+			t.Errorf("validatePlaceID(%.30q) = %v, want %v", test.ID, got, test.Want)
+		}
+		if got := IsValidPlaceID(test.ID); got != (test.Want == nil) {
+			t.Errorf("IsValidPlaceID(%.30q) = %v", test.ID, got)
+		}
+	}
+}
+
+func TestPlaceDetailsScope(t *testing.T) {
+	for _, test := range []struct {
+		Name   string
+		Place  PlaceDetails
+		Google string
+		App    string
+	}{
+		{
+			Name:   "no scope",
+			Place:  PlaceDetails{PlaceID: "google"},
+			Google: "google",
+			App:    "[]",
+		},
+		{
+			Name:   "google scope with previous app ID",
+			Place:  PlaceDetails{PlaceID: "google", Scope: ScopeGoogle, AltIDs: []AltID{{PlaceID: "app", Scope: ScopeApp}}},
+			Google: "google",
+			App:    "[app]",
+		},
+		{
+			Name:   "app scope",
+			Place:  PlaceDetails{PlaceID: "app", Scope: ScopeApp},
+			Google: "",
+			App:    "[app]",
+		},
+		{
+			Name:   "app scope with google alternative",
+			Place:  PlaceDetails{PlaceID: "app", Scope: ScopeApp, AltIDs: []AltID{{PlaceID: "google", Scope: ScopeGoogle}}},
+			Google: "google",
+			App:    "[app]",
+		},
+	} {
+		google, ok := test.Place.GooglePlaceID()
+		if google != test.Google || ok != (test.Google != "") {
+			t.Errorf("%s: GooglePlaceID() = %q, %v, want %q", test.Name, google, ok, test.Google)
+		}
+		if app := fmt.Sprint(test.Place.AppPlaceIDs()); app != test.App {
+			t.Errorf("%s: AppPlaceIDs() = %s, want %s", test.Name, app, test.App)
+		}
+	}
+}
+
+func TestMapsURLs(t *testing.T) {
+	opera := PlaceDetails{
+		PlaceID:          "ChIJ3S-JXmauEmsRUcIaWtf4MzE",
+		Name:             "Sydney Opera House",
+		FormattedAddress: "Bennelong Point, Sydney NSW 2000, Australia",
+	}
+	nearby := PlaceDetails{
+		PlaceID:  "ChIJN1t_tDeuEmsRUsoyG83frY4",
+		Name:     "Google",
+		Vicinity: "48 Pirrama Road, Pyrmont",
+		URL:      "https://maps.google.com/?cid=10281119596374313554",
+	}
+	unnamed := PlaceDetails{
+		PlaceID:  "app-id",
+		Scope:    ScopeApp,
+		Geometry: Geometry{Location: LatLng{Lat: -33.8587, Lng: 151.214}},
+	}
+
+	for _, test := range []struct {
+		Name string
+		Got  string
+		Want string
+	}{
+		{"search", MapsSearchURL("pizza in Seattle", ""), "https://www.google.com/maps/search/?api=1&query=pizza+in+Seattle"},
+		{"place", MapsPlaceURL("ChIJN1t_tDeuEmsRUsoyG83frY4"), "https://www.google.com/maps/place/?q=place_id:ChIJN1t_tDeuEmsRUsoyG83frY4"},
+		{
+			"directions",
+			Directions{Origin: "Google Pyrmont", OriginPlaceID: "ChIJN1t_tDeuEmsRUsoyG83frY4", Destination: "Sydney Opera House", TravelMode: Walking}.URL(),
+			"https://www.google.com/maps/dir/?api=1&destination=Sydney+Opera+House&origin=Google+Pyrmont&origin_place_id=ChIJN1t_tDeuEmsRUsoyG83frY4&travelmode=walking",
+		},
+		{"place search", opera.SearchURL(), "https://www.google.com/maps/search/?api=1&query=Sydney+Opera+House%2C+Bennelong+Point%2C+Sydney+NSW+2000%2C+Australia&query_place_id=ChIJ3S-JXmauEmsRUcIaWtf4MzE"},
+		{"place maps url without url", opera.MapsURL(), opera.SearchURL()},
+		{"place maps url", nearby.MapsURL(), "https://maps.google.com/?cid=10281119596374313554"},
+		{"place search by vicinity", nearby.SearchURL(), "https://www.google.com/maps/search/?api=1&query=Google%2C+48+Pirrama+Road%2C+Pyrmont&query_place_id=ChIJN1t_tDeuEmsRUsoyG83frY4"},
+		{"app scoped place search", unnamed.SearchURL(), "https://www.google.com/maps/search/?api=1&query=-33.858700%2C151.214000"},
+		{"place directions", opera.DirectionsURL(Transit), "https://www.google.com/maps/dir/?api=1&destination=Sydney+Opera+House%2C+Bennelong+Point%2C+Sydney+NSW+2000%2C+Australia&destination_place_id=ChIJ3S-JXmauEmsRUcIaWtf4MzE&travelmode=transit"},
+	} {
+		if test.Got != test.Want {
+			t.Errorf("%s URL = %s, want %s", test.Name, test.Got, test.Want)
+		}
+	}
+}
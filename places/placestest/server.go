@@ -0,0 +1,585 @@
+// Package placestest provides a fake Places API server for testing code that uses package places without sending requests to Google.
+//
+//	srv := placestest.NewServer(cafe, bakery)
+//	defer srv.Close()
+//	service := srv.Service()
+//	resp, err := service.Nearby(lat, lng).Do()
+package placestest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/maxhawkins/google-places-api/places"
+)
+
+// The limits the API applies to search results.
+const (
+	pageSize         = 20
+	maxSearchResults = 60
+	maxRadarResults  = 200
+)
+
+// DefaultTokenDelay is how long a page token takes to become valid unless TokenDelay is set, which is about as long as the API takes.
+const DefaultTokenDelay = 2 * time.Second
+
+// searchFields are the fields of a place that are included in search results. Details responses include every field, or those listed in the fields parameter.
+var searchFields = map[string]bool{
+	"business_status": true, "formatted_address": true, "geometry": true, "icon": true, "name": true,
+	"opening_hours": true, "permanently_closed": true, "photos": true, "place_id": true, "plus_code": true,
+	"price_level": true, "rating": true, "scope": true, "types": true, "user_ratings_total": true, "vicinity": true,
+}
+
+// png is a 1x1 transparent PNG image, served for every photo.
+var png = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00\x1f\x15\xc4\x89\x00\x00\x00\rIDATx\x9cc\x00\x01\x00\x00\x05\x00\x01\r\n-\xb4\x00\x00\x00\x00IEND\xaeB`\x82")
+
+// Server is a fake Places API that answers Nearby, Text and Radar Search, Details and Photo requests from a set of places held in memory. It checks that requests carry a key or client credentials and the parameters each endpoint requires, filters places by location, radius, type, keyword, name, price and whether they are open now, and pages search results with page tokens that, like the API's, only become valid after a delay. Failures can be scripted to test how code handles errors.
+//
+// Search results are ranked by rating for prominence and by distance for RankByDistance. Text Search matches a place if every word of the query appears in its name, address or types, and only returns places within the radius if a location is given.
+type Server struct {
+	// URL is the base URL of the server, to use with places.WithBaseURL.
+	URL string
+	// TokenDelay is how long a page token takes to become valid. Zero means DefaultTokenDelay; a negative delay makes tokens valid at once.
+	TokenDelay time.Duration
+	// Now returns the current time, used to decide whether places are open and when page tokens become valid. Defaults to time.Now.
+	Now func() time.Time
+
+	srv *httptest.Server
+
+	mu       sync.Mutex
+	places   []places.PlaceDetails
+	pages    map[string]page
+	failures []scriptedFailure
+	requests map[string][]url.Values
+	nextID   int
+}
+
+// page is a page of search results that can be fetched with a page token.
+type page struct {
+	results []places.PlaceDetails
+	validAt time.Time
+}
+
+// Failure is a response to send instead of the result of a request.
+type Failure struct {
+	// Status is the status of the response, e.g. "OVER_QUERY_LIMIT".
+	Status string
+	// ErrorMessage is the error_message of the response.
+	ErrorMessage string
+	// HTTPStatus, if set, makes the server reply with this HTTP status and a plain text body instead of a JSON response.
+	HTTPStatus int
+}
+
+type scriptedFailure struct {
+	endpoint string
+	Failure
+}
+
+// OverQueryLimit is the response to a request over the quota.
+func OverQueryLimit() Failure {
+	return Failure{Status: "OVER_QUERY_LIMIT", ErrorMessage: "You have exceeded your daily request quota for this API."}
+}
+
+// Unknown is the response to a request that failed on the server and may succeed if tried again, as recognised by places.IsUnknown.
+func Unknown() Failure {
+	return Failure{Status: "UNKNOWN"}
+}
+
+// RequestDenied is the response to a request with an invalid key.
+func RequestDenied() Failure {
+	return Failure{Status: "REQUEST_DENIED", ErrorMessage: "The provided API key is invalid."}
+}
+
+// ServerError is an HTTP error with the given status, e.g. http.StatusServiceUnavailable.
+func ServerError(status int) Failure {
+	return Failure{HTTPStatus: status}
+}
+
+// NewServer starts a server holding the given places. Places without a PlaceID are given one. It must be closed with Close.
+func NewServer(seed ...places.PlaceDetails) *Server {
+	s := &Server{
+		pages:    map[string]page{},
+		requests: map[string][]url.Values{},
+	}
+	s.Add(seed...)
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Service returns a service that sends its requests to the server with a test key, configured by any further options.
+func (s *Server) Service(opts ...places.Option) *places.Service {
+	return places.NewService(s.srv.Client(), "test-key", append([]places.Option{places.WithBaseURL(s.URL)}, opts...)...)
+}
+
+// Add adds places to the server, replacing any with the same PlaceID.
+func (s *Server) Add(ps ...places.PlaceDetails) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, p := range ps {
+		if p.PlaceID == "" {
+			s.nextID++
+			p.PlaceID = fmt.Sprintf("fake-place-%d", s.nextID)
+		}
+		if i := s.index(p.PlaceID); i >= 0 {
+			s.places[i] = p
+			continue
+		}
+		s.places = append(s.places, p)
+	}
+}
+
+// Remove removes the places with the given IDs, which are then not found.
+func (s *Server) Remove(ids ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		if i := s.index(id); i >= 0 {
+			s.places = append(s.places[:i:i], s.places[i+1:]...)
+		}
+	}
+}
+
+// Places returns the places held by the server.
+func (s *Server) Places() []places.PlaceDetails {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]places.PlaceDetails(nil), s.places...)
+}
+
+func (s *Server) index(id string) int {
+	for i := range s.places {
+		if s.places[i].PlaceID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Fail makes the next requests to endpoint, e.g. "nearbysearch" or "details", fail with the given failures, one request each, in order. An empty endpoint matches requests to any endpoint. Failures are used up in the order they were scripted, so requests to other endpoints do not see them.
+func (s *Server) Fail(endpoint string, failures ...Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, f := range failures {
+		s.failures = append(s.failures, scriptedFailure{endpoint: endpoint, Failure: f})
+	}
+}
+
+// Requests returns the query parameters of the requests made to endpoint, in order, including those that failed.
+func (s *Server) Requests(endpoint string) []url.Values {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]url.Values(nil), s.requests[endpoint]...)
+}
+
+func (s *Server) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func (s *Server) tokenDelay() time.Duration {
+	switch {
+	case s.TokenDelay < 0:
+		return 0
+	case s.TokenDelay == 0:
+		return DefaultTokenDelay
+	}
+	return s.TokenDelay
+}
+
+// response is the body of every JSON response.
+type response struct {
+	Status           string        `json:"status"`
+	ErrorMessage     string        `json:"error_message,omitempty"`
+	HTMLAttributions []string      `json:"html_attributions"`
+	Results          []interface{} `json:"results,omitempty"`
+	Result           interface{}   `json:"result,omitempty"`
+	NextPageToken    string        `json:"next_page_token,omitempty"`
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	endpoint := strings.TrimSuffix(path, "/json")
+	q := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests[endpoint] = append(s.requests[endpoint], q)
+
+	for i, f := range s.failures {
+		if f.endpoint == "" || f.endpoint == endpoint {
+			s.failures = append(s.failures[:i:i], s.failures[i+1:]...)
+			if f.HTTPStatus != 0 {
+				http.Error(w, http.StatusText(f.HTTPStatus), f.HTTPStatus)
+				return
+			}
+			writeJSON(w, response{Status: f.Status, ErrorMessage: f.ErrorMessage})
+			return
+		}
+	}
+
+	if q.Get("key") == "" && (q.Get("client") == "" || q.Get("signature") == "") {
+		writeJSON(w, response{Status: "REQUEST_DENIED", ErrorMessage: "You must use an API key to authenticate each request to Google Maps Platform APIs."})
+		return
+	}
+
+	var resp response
+	switch path {
+	case "nearbysearch/json":
+		resp = s.nearby(q)
+	case "textsearch/json":
+		resp = s.textSearch(q)
+	case "radarsearch/json":
+		resp = s.radar(q)
+	case "details/json":
+		resp = s.details(q)
+	case "photo":
+		s.photo(w, q)
+		return
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, resp)
+}
+
+func writeJSON(w http.ResponseWriter, resp response) {
+	if resp.HTMLAttributions == nil {
+		resp.HTMLAttributions = []string{}
+	}
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func invalid(message string) response {
+	return response{Status: "INVALID_REQUEST", ErrorMessage: message}
+}
+
+// query is the parsed parameters of a search.
+type query struct {
+	location    places.LatLng
+	hasLocation bool
+	radius      float64
+	distance    bool
+	types       string
+	keyword     string
+	name        string
+	words       []string
+	minPrice    int
+	maxPrice    int
+	openNow     bool
+}
+
+// parseQuery parses the search parameters shared by every search endpoint.
+func parseQuery(q url.Values) (query, string) {
+	p := query{
+		types:    q.Get("type"),
+		keyword:  strings.ToLower(q.Get("keyword")),
+		name:     strings.ToLower(q.Get("name")),
+		words:    strings.Fields(strings.ToLower(q.Get("query"))),
+		minPrice: 0,
+		maxPrice: 4,
+		openNow:  q.Get("opennow") != "" && q.Get("opennow") != "false",
+		distance: q.Get("rankby") == "distance",
+	}
+	if loc := q.Get("location"); loc != "" {
+		parts := strings.Split(loc, ",")
+		if len(parts) != 2 {
+			return p, "Invalid request. Invalid 'location' parameter."
+		}
+		lat, err1 := strconv.ParseFloat(parts[0], 64)
+		lng, err2 := strconv.ParseFloat(parts[1], 64)
+		if err1 != nil || err2 != nil {
+			return p, "Invalid request. Invalid 'location' parameter."
+		}
+		p.location, p.hasLocation = places.LatLng{Lat: lat, Lng: lng}, true
+	}
+	if radius := q.Get("radius"); radius != "" {
+		r, err := strconv.ParseFloat(radius, 64)
+		if err != nil || r <= 0 || r > 50000 {
+			return p, "Invalid request. Invalid 'radius' parameter."
+		}
+		p.radius = r
+	}
+	for _, price := range []struct {
+		name  string
+		value *int
+	}{{"minprice", &p.minPrice}, {"maxprice", &p.maxPrice}} {
+		if v := q.Get(price.name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 || n > 4 {
+				return p, "Invalid request. Invalid '" + price.name + "' parameter."
+			}
+			*price.value = n
+		}
+	}
+	return p, ""
+}
+
+// match reports whether place satisfies every filter of the query.
+func (q query) match(p *places.PlaceDetails, now time.Time) bool {
+	if q.radius > 0 && places.Distance(q.location, p.Geometry.Location) > q.radius {
+		return false
+	}
+	if q.types != "" && !hasType(p, q.types) {
+		return false
+	}
+	text := searchText(p)
+	if q.keyword != "" && !strings.Contains(text, q.keyword) {
+		return false
+	}
+	if q.name != "" && !strings.Contains(strings.ToLower(p.Name), q.name) {
+		return false
+	}
+	for _, word := range q.words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	if p.PriceLevel != nil && (int(*p.PriceLevel) < q.minPrice || int(*p.PriceLevel) > q.maxPrice) {
+		return false
+	}
+	if p.PriceLevel == nil && (q.minPrice > 0 || q.maxPrice < 4) {
+		return false
+	}
+	return !q.openNow || openNow(p, now)
+}
+
+// rank orders places by distance from the location for RankByDistance, or by rating and number of ratings otherwise.
+func (q query) rank(ps []places.PlaceDetails) {
+	sort.SliceStable(ps, func(i, j int) bool {
+		a, b := &ps[i], &ps[j]
+		if q.distance {
+			return places.Distance(q.location, a.Geometry.Location) < places.Distance(q.location, b.Geometry.Location)
+		}
+		if a.Rating != b.Rating {
+			return a.Rating > b.Rating
+		}
+		return a.UserRatingsTotal > b.UserRatingsTotal
+	})
+}
+
+func hasType(p *places.PlaceDetails, t string) bool {
+	for _, have := range p.Types {
+		if string(have) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// searchText returns the text a keyword or query is matched against, in lower case.
+func searchText(p *places.PlaceDetails) string {
+	parts := []string{p.Name, p.Vicinity, p.FormattedAddress}
+	for _, t := range p.Types {
+		parts = append(parts, strings.Replace(string(t), "_", " ", -1))
+	}
+	return strings.ToLower(strings.Join(parts, " "))
+}
+
+// openNow reports whether the place is open at now, by its opening periods if it has any and otherwise by its OpenNow flag.
+func openNow(p *places.PlaceDetails, now time.Time) bool {
+	if len(p.OpeningHours.Periods) > 0 {
+		return p.OpenAt(now)
+	}
+	return p.OpeningHours.OpenNow
+}
+
+// search returns the places matching q, ranked, up to limit.
+func (s *Server) search(q query, limit int) []places.PlaceDetails {
+	now := s.now()
+	var found []places.PlaceDetails
+	for i := range s.places {
+		if q.match(&s.places[i], now) {
+			found = append(found, s.places[i])
+		}
+	}
+	q.rank(found)
+	if len(found) > limit {
+		found = found[:limit]
+	}
+	return found
+}
+
+// paged returns the first page of results, storing the rest behind a page token.
+func (s *Server) paged(results []places.PlaceDetails) response {
+	if len(results) == 0 {
+		return response{Status: "ZERO_RESULTS"}
+	}
+	return s.page(results)
+}
+
+func (s *Server) page(results []places.PlaceDetails) response {
+	resp := response{Status: "OK"}
+	first := results
+	if len(results) > pageSize {
+		first = results[:pageSize]
+		s.nextID++
+		token := fmt.Sprintf("fake-page-token-%d", s.nextID)
+		s.pages[token] = page{results: results[pageSize:], validAt: s.now().Add(s.tokenDelay())}
+		resp.NextPageToken = token
+	}
+	now := s.now()
+	for i := range first {
+		result := render(&first[i], searchFields, now)
+		if hours, ok := result["opening_hours"].(places.OpeningHours); ok {
+			// Search results only say whether the place is open now.
+			result["opening_hours"] = map[string]bool{"open_now": hours.OpenNow}
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp
+}
+
+// nextPage returns the page of results for a page token.
+func (s *Server) nextPage(token string) response {
+	p, ok := s.pages[token]
+	if !ok || s.now().Before(p.validAt) {
+		// The API does not distinguish between unknown tokens and those that are not valid yet.
+		return invalid("")
+	}
+	return s.page(p.results)
+}
+
+func (s *Server) nearby(q url.Values) response {
+	if token := q.Get("pagetoken"); token != "" {
+		return s.nextPage(token)
+	}
+	p, msg := parseQuery(q)
+	switch {
+	case msg != "":
+		return invalid(msg)
+	case !p.hasLocation:
+		return invalid("Invalid request. Missing the 'location' parameter.")
+	case p.distance && p.radius > 0:
+		return invalid("Invalid request. 'radius' must not be included if 'rankby=distance' is specified.")
+	case p.distance && p.types == "" && p.keyword == "" && p.name == "":
+		return invalid("Invalid request. 'rankby=distance' requires a 'keyword', 'name' or 'type' parameter.")
+	case !p.distance && p.radius == 0:
+		return invalid("Invalid request. Missing the 'radius' parameter.")
+	}
+	return s.paged(s.search(p, maxSearchResults))
+}
+
+func (s *Server) textSearch(q url.Values) response {
+	if token := q.Get("pagetoken"); token != "" {
+		return s.nextPage(token)
+	}
+	p, msg := parseQuery(q)
+	switch {
+	case msg != "":
+		return invalid(msg)
+	case len(p.words) == 0 && p.types == "":
+		return invalid("Invalid request. Missing the 'query' parameter.")
+	}
+	if !p.hasLocation {
+		p.radius = 0
+	}
+	return s.paged(s.search(p, maxSearchResults))
+}
+
+func (s *Server) radar(q url.Values) response {
+	if q.Get("pagetoken") != "" {
+		return invalid("Invalid request. Radar Search does not return pages.")
+	}
+	p, msg := parseQuery(q)
+	switch {
+	case msg != "":
+		return invalid(msg)
+	case !p.hasLocation || p.radius == 0:
+		return invalid("Invalid request. Missing the 'location' or 'radius' parameter.")
+	case p.types == "" && p.keyword == "" && p.name == "":
+		return invalid("Invalid request. Radar Search requires a 'keyword', 'name' or 'type' parameter.")
+	}
+	found := s.search(p, maxRadarResults)
+	if len(found) == 0 {
+		return response{Status: "ZERO_RESULTS"}
+	}
+	resp := response{Status: "OK"}
+	for i := range found {
+		resp.Results = append(resp.Results, render(&found[i], map[string]bool{"place_id": true, "geometry": true}, s.now()))
+	}
+	return resp
+}
+
+func (s *Server) details(q url.Values) response {
+	id := q.Get("placeid")
+	if id == "" {
+		id = q.Get("place_id")
+	}
+	if id == "" {
+		return invalid("Invalid request. Missing the 'placeid' parameter.")
+	}
+	i := s.index(id)
+	if i < 0 {
+		return response{Status: "NOT_FOUND"}
+	}
+	return response{Status: "OK", Result: render(&s.places[i], detailsFields(q), s.now())}
+}
+
+// detailsFields returns the fields asked for by the fields parameter of a Details request, or nil for every field if there is none. Paths such as geometry/location select the whole top-level field.
+func detailsFields(q url.Values) map[string]bool {
+	if q.Get("fields") == "" {
+		return nil
+	}
+	fields := map[string]bool{}
+	for _, field := range strings.Split(q.Get("fields"), ",") {
+		fields[strings.Split(strings.TrimSpace(field), "/")[0]] = true
+	}
+	return fields
+}
+
+func (s *Server) photo(w http.ResponseWriter, q url.Values) {
+	ref := q.Get("photoreference")
+	if ref == "" || (q.Get("maxwidth") == "" && q.Get("maxheight") == "") {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+	for i := range s.places {
+		for _, photo := range s.places[i].Photos {
+			if photo.PhotoReference == ref {
+				w.Header().Set("Content-Type", "image/png")
+				w.Write(png)
+				return
+			}
+		}
+	}
+	http.Error(w, "Bad Request", http.StatusBadRequest)
+}
+
+// render returns the JSON form of a place with only the given fields, or all of them if fields is nil, leaving out those with zero values as the API does. Whether the place is open now is worked out from its opening periods.
+func render(p *places.PlaceDetails, fields map[string]bool, now time.Time) map[string]interface{} {
+	out := map[string]interface{}{}
+	v := reflect.ValueOf(*p)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || (fields != nil && !fields[name]) {
+			continue
+		}
+		if f := v.Field(i); !f.IsZero() {
+			out[name] = f.Interface()
+		}
+	}
+	if hours, ok := out["opening_hours"].(places.OpeningHours); ok {
+		hours.OpenNow = openNow(p, now)
+		out["opening_hours"] = hours
+	}
+	return out
+}
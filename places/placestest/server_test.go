@@ -0,0 +1,331 @@
+package placestest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/maxhawkins/google-places-api/places"
+)
+
+// clock is a fake time that tests can move forward.
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func price(p places.PriceLevel) *places.PriceLevel {
+	return &p
+}
+
+// seed returns a cafe, a bakery and a bar around the origin, followed by n more cafes each further east.
+func seed(n int) []places.PlaceDetails {
+	weekdays := []places.Period{}
+	for day := 1; day <= 5; day++ {
+		weekdays = append(weekdays, places.Period{
+			Open:  places.DayTime{Day: day, Time: "0900"},
+			Close: places.DayTime{Day: day, Time: "1700"},
+		})
+	}
+	ps := []places.PlaceDetails{
+		{PlaceID: "cafe", Name: "Corner Cafe", Vicinity: "1 Main St", Rating: 4.5, Types: []places.FeatureType{places.Cafe, places.Food}, PriceLevel: price(places.Inexpensive), Website: "https://cafe.example", OpeningHours: places.OpeningHours{Periods: weekdays}},
+		{PlaceID: "bakery", Name: "Daily Bread", Vicinity: "2 Main St", Rating: 4.8, Types: []places.FeatureType{places.Bakery, places.Food}, PriceLevel: price(places.Moderate), Geometry: places.Geometry{Location: places.LatLng{Lat: 0.001}}},
+		{PlaceID: "bar", Name: "Night Owl", Vicinity: "3 High St", Rating: 4.1, Types: []places.FeatureType{places.Bar}, Geometry: places.Geometry{Location: places.LatLng{Lat: 0.002}}, Photos: []places.Photo{{PhotoReference: "owl", Width: 100, Height: 100}}},
+	}
+	for i := 0; i < n; i++ {
+		ps = append(ps, places.PlaceDetails{
+			Name:     fmt.Sprintf("Espresso %d", i),
+			Types:    []places.FeatureType{places.Cafe},
+			Geometry: places.Geometry{Location: places.LatLng{Lng: 0.0001 * float64(i+1)}},
+		})
+	}
+	return ps
+}
+
+func ids(resp *places.SearchResponse) string {
+	var got []string
+	for _, r := range resp.Results {
+		got = append(got, r.PlaceID)
+	}
+	return fmt.Sprint(got)
+}
+
+func TestNearby(t *testing.T) {
+	c := &clock{now: time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)} // A Monday.
+	srv := NewServer(seed(0)...)
+	defer srv.Close()
+	srv.Now = c.Now
+	service := srv.Service()
+
+	for _, test := range []struct {
+		Name  string
+		Setup func(*places.NearbyCall)
+		Want  string
+	}{
+		{"prominence", func(n *places.NearbyCall) { n.Radius = 1000 }, "[bakery cafe bar]"},
+		{"radius", func(n *places.NearbyCall) { n.Radius = 150 }, "[bakery cafe]"},
+		{"distance", func(n *places.NearbyCall) { n.RankBy = places.RankByDistance; n.Keyword = "st" }, "[cafe bakery bar]"},
+		{"type", func(n *places.NearbyCall) { n.Radius = 1000; n.Type = places.Bar }, "[bar]"},
+		{"keyword matches address", func(n *places.NearbyCall) { n.Radius = 1000; n.Keyword = "high st" }, "[bar]"},
+		{"name", func(n *places.NearbyCall) { n.Radius = 1000; n.Name = "bread" }, "[bakery]"},
+		{"price", func(n *places.NearbyCall) { n.Radius = 1000; n.MaxPrice = price(places.Inexpensive) }, "[cafe]"},
+		{"open now", func(n *places.NearbyCall) { n.Radius = 1000; n.OpenNow = true }, "[cafe]"},
+	} {
+		call := service.Nearby(0, 0)
+		test.Setup(call)
+		resp, err := call.Do()
+		if err != nil {
+			t.Errorf("%s: Do() = %v", test.Name, err)
+			continue
+		}
+		if got := ids(resp); got != test.Want {
+			t.Errorf("%s: results = %s, want %s", test.Name, got, test.Want)
+		}
+	}
+
+	call := service.Nearby(0, 0)
+	call.Radius = 1000
+	call.Keyword = "nothing like this"
+	if _, err := call.Do(); !places.IsZeroResults(err) {
+		t.Errorf("Do() without matches = %v, want ZERO_RESULTS", err)
+	}
+}
+
+func TestSearchResultFields(t *testing.T) {
+	c := &clock{now: time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)}
+	srv := NewServer(seed(0)...)
+	defer srv.Close()
+	srv.Now = c.Now
+	service := srv.Service()
+
+	call := service.Nearby(0, 0)
+	call.Name = "corner"
+	call.Radius = 100
+	resp, err := call.Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cafe := resp.Results[0]
+	if cafe.Website != "" || cafe.Has("website") || len(cafe.OpeningHours.Periods) != 0 || !cafe.OpeningHours.OpenNow {
+		t.Errorf("search result = %+v, want only search fields, with open_now set", cafe)
+	}
+	if cafe.Has("user_ratings_total") {
+		t.Error("search result has a field that is zero in the dataset")
+	}
+
+	details, err := service.Details("cafe").Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if details.Result.Website != "https://cafe.example" || len(details.Result.OpeningHours.Periods) != 5 {
+		t.Errorf("details = %+v, want every field", details.Result)
+	}
+	if _, err := service.Details("missing").Do(); !places.IsNotFound(err) {
+		t.Errorf("Details() of a missing place = %v, want NOT_FOUND", err)
+	}
+}
+
+func TestDetailsFields(t *testing.T) {
+	srv := NewServer(seed(0)...)
+	defer srv.Close()
+
+	for _, test := range []struct {
+		Fields string
+		Want   string
+	}{
+		{"", "[geometry name place_id price_level rating types vicinity]"},
+		{"name,rating", "[name rating]"},
+		{"place_id, geometry/location,website", "[geometry place_id]"},
+	} {
+		q := url.Values{"placeid": {"bakery"}, "key": {"k"}}
+		if test.Fields != "" {
+			q.Set("fields", test.Fields)
+		}
+		resp, err := http.Get(srv.URL + "/details/json?" + q.Encode())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var body struct {
+			Result map[string]json.RawMessage `json:"result"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for name := range body.Result {
+			got = append(got, name)
+		}
+		sort.Strings(got)
+		if fmt.Sprint(got) != test.Want {
+			t.Errorf("details with fields %q = %v, want %v", test.Fields, got, test.Want)
+		}
+	}
+}
+
+func TestPagination(t *testing.T) {
+	c := &clock{now: time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)}
+	srv := NewServer(seed(70)...)
+	defer srv.Close()
+	srv.Now = c.Now
+	service := srv.Service()
+
+	call := service.Nearby(0, 0)
+	call.Radius = 50000
+	call.Type = places.Cafe
+	var counts []int
+	for {
+		resp, err := call.Do()
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts = append(counts, len(resp.Results))
+		if resp.NextPageToken == "" {
+			break
+		}
+
+		next := service.Nearby(0, 0)
+		next.PageToken = resp.NextPageToken
+		if _, err := next.Do(); !places.IsInvalidRequest(err) {
+			t.Errorf("page token used at once = %v, want INVALID_REQUEST", err)
+		}
+		c.advance(DefaultTokenDelay)
+		call = next
+	}
+	if got := fmt.Sprint(counts); got != "[20 20 20]" {
+		t.Errorf("page sizes = %s, want three pages of 20", got)
+	}
+
+	next := service.Nearby(0, 0)
+	next.PageToken = "made-up"
+	if _, err := next.Do(); !places.IsInvalidRequest(err) {
+		t.Errorf("unknown page token = %v, want INVALID_REQUEST", err)
+	}
+}
+
+func TestTextAndRadarSearch(t *testing.T) {
+	srv := NewServer(seed(3)...)
+	defer srv.Close()
+	service := srv.Service()
+
+	resp, err := service.TextSearch("main st food").Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(resp); got != "[bakery cafe]" {
+		t.Errorf("TextSearch() = %s, want [bakery cafe]", got)
+	}
+
+	call := service.RadarSearch(1000, 0, 0)
+	call.Keyword = "espresso"
+	radar, err := call.Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(radar.Results) != 3 || radar.Results[0].Name != "" || radar.Results[0].PlaceID == "" {
+		t.Errorf("RadarSearch() = %+v, want three results with only IDs and locations", radar.Results)
+	}
+}
+
+func TestFail(t *testing.T) {
+	srv := NewServer(seed(0)...)
+	defer srv.Close()
+	service := srv.Service()
+
+	srv.Fail("details", OverQueryLimit(), Unknown(), ServerError(http.StatusServiceUnavailable))
+	srv.Fail("", RequestDenied())
+	for _, test := range []struct {
+		Name  string
+		Check func(error) bool
+	}{
+		{"over query limit", places.IsOverQueryLimit},
+		{"unknown", places.IsUnknown},
+		{"server error", places.IsServerError},
+		{"request denied", places.IsRequestDenied},
+		{"recovered", func(err error) bool { return err == nil }},
+	} {
+		if _, err := service.Details("cafe").Do(); !test.Check(err) {
+			t.Errorf("%s: Details() = %v", test.Name, err)
+		}
+	}
+	if n := len(srv.Requests("details")); n != 5 {
+		t.Errorf("Requests() = %d, want 5", n)
+	}
+
+	srv.Fail("nearbysearch", OverQueryLimit())
+	if _, err := service.Details("cafe").Do(); err != nil {
+		t.Errorf("Details() with a failure scripted for another endpoint = %v", err)
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	srv := NewServer(seed(0)...)
+	defer srv.Close()
+
+	service := places.NewService(http.DefaultClient, "", places.WithBaseURL(srv.URL))
+	if _, err := service.Details("cafe").Do(); !places.IsRequestDenied(err) {
+		t.Errorf("Details() without a key = %v, want REQUEST_DENIED", err)
+	}
+}
+
+func TestAddRemove(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	service := srv.Service()
+
+	srv.Add(places.PlaceDetails{Name: "Unnamed"}, places.PlaceDetails{PlaceID: "a", Name: "First"})
+	srv.Add(places.PlaceDetails{PlaceID: "a", Name: "Second"})
+	if ps := srv.Places(); len(ps) != 2 || ps[0].PlaceID == "" || ps[1].Name != "Second" {
+		t.Errorf("Places() = %+v", ps)
+	}
+	srv.Remove("a")
+	if _, err := service.Details("a").Do(); !places.IsNotFound(err) {
+		t.Errorf("Details() of a removed place = %v, want NOT_FOUND", err)
+	}
+}
+
+func TestPhoto(t *testing.T) {
+	srv := NewServer(seed(0)...)
+	defer srv.Close()
+
+	for _, test := range []struct {
+		Query  url.Values
+		Status int
+	}{
+		{url.Values{"photoreference": {"owl"}, "maxwidth": {"400"}, "key": {"k"}}, http.StatusOK},
+		{url.Values{"photoreference": {"owl"}, "key": {"k"}}, http.StatusBadRequest},
+		{url.Values{"photoreference": {"unknown"}, "maxheight": {"400"}, "key": {"k"}}, http.StatusBadRequest},
+	} {
+		resp, err := http.Get(srv.URL + "/photo?" + test.Query.Encode())
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != test.Status {
+			t.Errorf("photo %v: status %d, want %d", test.Query, resp.StatusCode, test.Status)
+		}
+		if test.Status == http.StatusOK && (resp.Header.Get("Content-Type") != "image/png" || len(body) == 0) {
+			t.Errorf("photo %v: %s with %d bytes, want a PNG", test.Query, resp.Header.Get("Content-Type"), len(body))
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package places
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+var (
+	errInvalidPlusCode = errors.New("the plus code is not valid")
+	errInvalidCodeLen  = errors.New("plus code length must be 2, 4, 6, 8 or at least 10 digits")
+	errNotFullCode     = errors.New("the plus code is not a full code")
+	errPaddedPlusCode  = errors.New("a padded plus code cannot be shortened")
+	errMissingPlusCode = errors.New("the place has no plus code")
+	errInvalidRef      = errors.New("the reference location must be a finite latitude and longitude")
+)
+
+// DefaultPlusCodeLength is the number of digits in a plus code identifying an area of about 14m by 14m, the precision returned by the API.
+const DefaultPlusCodeLength = 10
+
+// Open Location Code parameters, as described in https://github.com/google/open-location-code/blob/main/docs/specification.md.
+const (
+	plusCodeAlphabet  = "23456789CFGHJMPQRVWX"
+	plusCodeBase      = 20
+	plusCodeSeparator = '+'
+	plusCodePadding   = '0'
+	separatorPosition = 8
+	pairCodeLength    = 10
+	gridCodeLength    = 5
+	maxCodeLength     = pairCodeLength + gridCodeLength
+	gridColumns       = 4
+	gridRows          = 5
+
+	// pairPrecision is the number of steps per degree of the last pair of digits.
+	pairPrecision = plusCodeBase * plusCodeBase * plusCodeBase
+	// finalLatPrecision and finalLngPrecision are the number of steps per degree of the longest code.
+	finalLatPrecision = pairPrecision * gridRows * gridRows * gridRows * gridRows * gridRows
+	finalLngPrecision = pairPrecision * gridColumns * gridColumns * gridColumns * gridColumns * gridColumns
+)
+
+// pairResolutions is the size in degrees of the area identified by each pair of digits.
+var pairResolutions = []float64{20, 1, 0.05, 0.0025, 0.000125}
+
+// CodeArea is the area identified by a plus code.
+type CodeArea struct {
+	// The south-west corner of the area.
+	LatLo, LngLo float64
+	// The north-east corner of the area.
+	LatHi, LngHi float64
+	// The number of digits in the code, excluding the separator and padding.
+	Len int
+}
+
+// Center returns the center of the area, clipped to the valid range of latitude and longitude.
+func (a CodeArea) Center() LatLng {
+	return LatLng{
+		Lat: math.Min((a.LatLo+a.LatHi)/2, 90),
+		Lng: math.Min((a.LngLo+a.LngHi)/2, 180),
+	}
+}
+
+// EncodePlusCode returns the full plus code of the given length for loc, e.g. "849VCWC8+R9". Longer codes identify smaller areas: 10 digits is about 14m square and every further digit divides the area by 20. Lengths below 10 must be even and are padded with zeros, e.g. "849V0000+".
+func EncodePlusCode(loc LatLng, length int) (string, error) {
+	if length < 2 || (length < pairCodeLength && length%2 == 1) {
+		return "", errInvalidCodeLen
+	}
+	if length > maxCodeLength {
+		length = maxCodeLength
+	}
+
+	// Work in integer steps of the finest grid so that rounding is the same at every length.
+	latVal := int64(math.Floor(math.Round((clipLatitude(loc.Lat)+90)*finalLatPrecision*1e6) / 1e6))
+	lngVal := int64(math.Floor(math.Round((loc.Lng+180)*finalLngPrecision*1e6) / 1e6))
+	if latVal >= 180*finalLatPrecision {
+		latVal = 180*finalLatPrecision - 1
+	}
+	lngVal %= 360 * finalLngPrecision
+	if lngVal < 0 {
+		lngVal += 360 * finalLngPrecision
+	}
+
+	digits := make([]byte, maxCodeLength)
+	for i := maxCodeLength - 1; i >= pairCodeLength; i-- {
+		digits[i] = plusCodeAlphabet[(latVal%gridRows)*gridColumns+lngVal%gridColumns]
+		latVal /= gridRows
+		lngVal /= gridColumns
+	}
+	for i := pairCodeLength - 2; i >= 0; i -= 2 {
+		digits[i] = plusCodeAlphabet[latVal%plusCodeBase]
+		digits[i+1] = plusCodeAlphabet[lngVal%plusCodeBase]
+		latVal /= plusCodeBase
+		lngVal /= plusCodeBase
+	}
+
+	code := string(digits[:length])
+	if length < separatorPosition {
+		return code + strings.Repeat(string(plusCodePadding), separatorPosition-length) + string(plusCodeSeparator), nil
+	}
+	return code[:separatorPosition] + string(plusCodeSeparator) + code[separatorPosition:], nil
+}
+
+// DecodePlusCode returns the area identified by a full plus code.
+func DecodePlusCode(code string) (CodeArea, error) {
+	if !IsFullPlusCode(code) {
+		return CodeArea{}, errNotFullCode
+	}
+	digits := strings.NewReplacer(string(plusCodeSeparator), "", string(plusCodePadding), "").Replace(strings.ToUpper(code))
+	if len(digits) > maxCodeLength {
+		digits = digits[:maxCodeLength]
+	}
+
+	var lat, lng int64 = -90 * pairPrecision, -180 * pairPrecision
+	place := int64(pairPrecision * plusCodeBase)
+	pairs := len(digits)
+	if pairs > pairCodeLength {
+		pairs = pairCodeLength
+	}
+	for i := 0; i < pairs; i += 2 {
+		lat += int64(strings.IndexByte(plusCodeAlphabet, digits[i])) * place
+		lng += int64(strings.IndexByte(plusCodeAlphabet, digits[i+1])) * place
+		if i < pairs-2 {
+			place /= plusCodeBase
+		}
+	}
+	latSize := float64(place) / pairPrecision
+	lngSize := latSize
+
+	var gridLat, gridLng int64
+	if len(digits) > pairCodeLength {
+		row := int64(finalLatPrecision / pairPrecision / gridRows)
+		col := int64(finalLngPrecision / pairPrecision / gridColumns)
+		for i := pairCodeLength; i < len(digits); i++ {
+			d := int64(strings.IndexByte(plusCodeAlphabet, digits[i]))
+			gridLat += d / gridColumns * row
+			gridLng += d % gridColumns * col
+			if i < len(digits)-1 {
+				row /= gridRows
+				col /= gridColumns
+			}
+		}
+		latSize = float64(row) / finalLatPrecision
+		lngSize = float64(col) / finalLngPrecision
+	}
+
+	latLo := float64(lat)/pairPrecision + float64(gridLat)/finalLatPrecision
+	lngLo := float64(lng)/pairPrecision + float64(gridLng)/finalLngPrecision
+	return CodeArea{
+		LatLo: latLo,
+		LngLo: lngLo,
+		LatHi: latLo + latSize,
+		LngHi: lngLo + lngSize,
+		Len:   len(digits),
+	}, nil
+}
+
+// ShortenPlusCode removes as many leading digits from a full plus code as can be recovered using a reference location near it, such as the center of the town it is in. The result, e.g. "CWC8+R9", can be turned back into the full code with RecoverPlusCode and a reference location within about half a degree of the original one.
+func ShortenPlusCode(code string, ref LatLng) (string, error) {
+	if !IsFullPlusCode(code) {
+		return "", errNotFullCode
+	}
+	if strings.IndexByte(code, plusCodePadding) >= 0 {
+		return "", errPaddedPlusCode
+	}
+	if !ref.finite() {
+		return "", errInvalidRef
+	}
+	code = strings.ToUpper(code)
+
+	area, err := DecodePlusCode(code)
+	if err != nil {
+		return "", err
+	}
+	center := area.Center()
+	distance := math.Max(
+		math.Abs(center.Lat-clipLatitude(ref.Lat)),
+		math.Abs(center.Lng-normalizeLongitude(ref.Lng)),
+	)
+
+	// Remove 8, 6 or 4 digits, as long as the reference is well within the area the remaining digits identify.
+	for i := len(pairResolutions) - 2; i >= 1; i-- {
+		if distance < pairResolutions[i]*0.3 {
+			return code[(i+1)*2:], nil
+		}
+	}
+	return code, nil
+}
+
+// RecoverPlusCode returns the full plus code nearest to ref that ends with the short code. Full codes are returned unchanged, apart from being upper-cased.
+func RecoverPlusCode(short string, ref LatLng) (string, error) {
+	if IsFullPlusCode(short) {
+		return strings.ToUpper(short), nil
+	}
+	if !IsShortPlusCode(short) {
+		return "", errInvalidPlusCode
+	}
+	if !ref.finite() {
+		return "", errInvalidRef
+	}
+	short = strings.ToUpper(short)
+	ref = LatLng{Lat: clipLatitude(ref.Lat), Lng: normalizeLongitude(ref.Lng)}
+
+	// Take the missing leading digits from the reference location.
+	missing := separatorPosition - strings.IndexByte(short, plusCodeSeparator)
+	prefix, err := EncodePlusCode(ref, DefaultPlusCodeLength)
+	if err != nil {
+		return "", err
+	}
+	area, err := DecodePlusCode(prefix[:missing] + short)
+	if err != nil {
+		return "", err
+	}
+
+	// The nearest matching area may be in the neighbouring cell of the missing digits.
+	resolution := math.Pow(plusCodeBase, 2-float64(missing)/2)
+	half := resolution / 2
+	center := area.Center()
+	if ref.Lat+half < center.Lat && center.Lat-resolution >= -90 {
+		center.Lat -= resolution
+	} else if ref.Lat-half > center.Lat && center.Lat+resolution <= 90 {
+		center.Lat += resolution
+	}
+	if ref.Lng+half < center.Lng {
+		center.Lng -= resolution
+	} else if ref.Lng-half > center.Lng {
+		center.Lng += resolution
+	}
+	return EncodePlusCode(center, area.Len)
+}
+
+// IsValidPlusCode returns true if code is a valid full or short plus code. The comparison ignores case.
+func IsValidPlusCode(code string) bool {
+	sep := strings.IndexByte(code, plusCodeSeparator)
+	if sep < 0 || sep != strings.LastIndexByte(code, plusCodeSeparator) || sep > separatorPosition || sep%2 == 1 {
+		return false
+	}
+	// A code needs at least one digit, and a single digit after the separator is not allowed.
+	if len(code) == 1 || len(code)-sep-1 == 1 {
+		return false
+	}
+
+	code = strings.ToUpper(code)
+	if pad := strings.IndexByte(code, plusCodePadding); pad >= 0 {
+		// Padding must follow an even number of digits, fill the rest of the code before the separator and end the code.
+		end := strings.LastIndexByte(code, plusCodePadding)
+		if pad == 0 || pad%2 == 1 || end != sep-1 || sep != separatorPosition || sep != len(code)-1 {
+			return false
+		}
+		code = code[:pad] + code[sep:]
+	}
+	for i := 0; i < len(code); i++ {
+		if code[i] != plusCodeSeparator && strings.IndexByte(plusCodeAlphabet, code[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsShortPlusCode returns true if code is a valid short plus code, which needs a reference location to identify an area.
+func IsShortPlusCode(code string) bool {
+	return IsValidPlusCode(code) && strings.IndexByte(code, plusCodeSeparator) < separatorPosition
+}
+
+// IsFullPlusCode returns true if code is a valid full plus code, which identifies an area without a reference location.
+func IsFullPlusCode(code string) bool {
+	if !IsValidPlusCode(code) || IsShortPlusCode(code) {
+		return false
+	}
+	code = strings.ToUpper(code)
+	// The first digits must not point beyond the poles or the antimeridian.
+	if strings.IndexByte(plusCodeAlphabet, code[0])*plusCodeBase >= 180 {
+		return false
+	}
+	return len(code) < 2 || strings.IndexByte(plusCodeAlphabet, code[1])*plusCodeBase < 360
+}
+
+// Decode returns the area identified by the plus code. The global code is used if it is set; otherwise the short code at the start of the compound code is recovered using ref, which should be near the place, e.g. its location.
+func (p PlusCode) Decode(ref LatLng) (CodeArea, error) {
+	code := p.GlobalCode
+	if code == "" && p.CompoundCode != "" {
+		fields := strings.Fields(p.CompoundCode)
+		if len(fields) == 0 {
+			return CodeArea{}, errInvalidPlusCode
+		}
+		var err error
+		if code, err = RecoverPlusCode(fields[0], ref); err != nil {
+			return CodeArea{}, err
+		}
+	}
+	if code == "" {
+		return CodeArea{}, errMissingPlusCode
+	}
+	return DecodePlusCode(code)
+}
+
+// PlusCodeArea returns the area identified by the place's plus code.
+func (p *PlaceDetails) PlusCodeArea() (CodeArea, error) {
+	return p.PlusCode.Decode(p.Geometry.Location)
+}
+
+func clipLatitude(lat float64) float64 {
+	return math.Min(90, math.Max(-90, lat))
+}
+
+// normalizeLongitude maps lng into the range [-180, 180). Infinite longitudes become NaN.
+func normalizeLongitude(lng float64) float64 {
+	lng = math.Mod(lng+180, 360)
+	if lng < 0 {
+		lng += 360
+	}
+	return lng - 180
+}
+
+// finite reports whether l is neither NaN nor infinite.
+func (l LatLng) finite() bool {
+	return !math.IsNaN(l.Lat) && !math.IsInf(l.Lat, 0) && !math.IsNaN(l.Lng) && !math.IsInf(l.Lng, 0)
+}
@@ -0,0 +1,255 @@
+package places
+
+import (
+	"math"
+	"testing"
+)
+
+// Test cases are taken from the Open Location Code test data at https://github.com/google/open-location-code/tree/main/test_data.
+
+func TestEncodePlusCode(t *testing.T) {
+	for _, test := range []struct {
+		Lat, Lng float64
+		Length   int
+		Want     string
+	}{
+		{20.375, 2.775, 6, "7FG49Q00+"},
+		{20.3700625, 2.7821875, 10, "7FG49QCJ+2V"},
+		{20.3701125, 2.782234375, 11, "7FG49QCJ+2VX"},
+		{20.3701135, 2.78223535156, 13, "7FG49QCJ+2VXGJ"},
+		{47.0000625, 8.0000625, 10, "8FVC2222+22"},
+		{-41.2730625, 174.7859375, 10, "4VCPPQGP+Q9"},
+		{0.5, -179.5, 4, "62G20000+"},
+		{-89.5, -179.5, 4, "22220000+"},
+		{20.5, 2.5, 4, "7FG40000+"},
+		{-89.9999375, -179.9999375, 10, "22222222+22"},
+		{0.5, 179.5, 4, "6VGX0000+"},
+		{1, 1, 11, "6FH32222+222"},
+		{90, 1, 4, "CFX30000+"},
+		{92, 1, 4, "CFX30000+"},
+		{1, 180, 4, "62H20000+"},
+		{1, 181, 4, "62H30000+"},
+		{90, 1, 10, "CFX3X2X2+X2"},
+		{1, 1, 16, "6FH32222+2222222"},
+	} {
+		got, err := EncodePlusCode(LatLng{test.Lat, test.Lng}, test.Length)
+		if err != nil || got != test.Want {
+			t.Errorf("EncodePlusCode(%v, %v, %d) = %q, %v, want %q", test.Lat, test.Lng, test.Length, got, err, test.Want)
+		}
+	}
+
+	for _, length := range []int{0, 1, 3, 9} {
+		if _, err := EncodePlusCode(LatLng{}, length); err != errInvalidCodeLen {
+			t.Errorf("EncodePlusCode() with length %d = %v, want %v", length, err, errInvalidCodeLen)
+		}
+	}
+}
+
+func TestDecodePlusCode(t *testing.T) {
+	for _, test := range []struct {
+		Code string
+		Want CodeArea
+	}{
+		{"7FG49Q00+", CodeArea{20.35, 2.75, 20.4, 2.8, 6}},
+		{"7FG49QCJ+2V", CodeArea{20.37, 2.782125, 20.370125, 2.78225, 10}},
+		{"7fg49qcj+2vx", CodeArea{20.3701, 2.78221875, 20.370125, 2.78225, 11}},
+		{"7FG49QCJ+2VXGJ", CodeArea{20.370113, 2.782234375, 20.370114, 2.78223632813, 13}},
+		{"8FVC2222+22", CodeArea{47.0, 8.0, 47.000125, 8.000125, 10}},
+		{"4VCPPQGP+Q9", CodeArea{-41.273125, 174.785875, -41.273, 174.786, 10}},
+		{"62G20000+", CodeArea{0.0, -180.0, 1, -179, 4}},
+		{"CFX30000+", CodeArea{89, 1, 90, 2, 4}},
+	} {
+		got, err := DecodePlusCode(test.Code)
+		if err != nil {
+			t.Errorf("DecodePlusCode(%q) = %v", test.Code, err)
+			continue
+		}
+		if got.Len != test.Want.Len ||
+			math.Abs(got.LatLo-test.Want.LatLo) > 1e-9 || math.Abs(got.LngLo-test.Want.LngLo) > 1e-9 ||
+			math.Abs(got.LatHi-test.Want.LatHi) > 1e-9 || math.Abs(got.LngHi-test.Want.LngHi) > 1e-9 {
+			t.Errorf("DecodePlusCode(%q) = %+v, want %+v", test.Code, got, test.Want)
+		}
+	}
+
+	for _, code := range []string{"9QCJ+2VX", "7FG49QCJ2VX", "WFG49QCJ+2V", ""} {
+		if _, err := DecodePlusCode(code); err != errNotFullCode {
+			t.Errorf("DecodePlusCode(%q) = %v, want %v", code, err, errNotFullCode)
+		}
+	}
+}
+
+func TestPlusCodeValidity(t *testing.T) {
+	for _, test := range []struct {
+		Code               string
+		Valid, Short, Full bool
+	}{
+		{"8FWC2345+G6", true, false, true},
+		{"8FWC2345+G6G", true, false, true},
+		{"8fwc2345+", true, false, true},
+		{"8FWCX400+", true, false, true},
+		{"WC2345+G6g", true, true, false},
+		{"2345+G6", true, true, false},
+		{"45+G6", true, true, false},
+		{"+G6", true, true, false},
+		{"G+", false, false, false},
+		{"+", false, false, false},
+		{"8FWC2345+G", false, false, false},
+		{"8FWC2_45+G6", false, false, false},
+		{"8FWC2η45+G6", false, false, false},
+		{"8FWC2345+G6+", false, false, false},
+		{"8FWC2345G6+", false, false, false},
+		{"8FWC2300+G6", false, false, false},
+		{"WC2300+G6g", false, false, false},
+		{"WC2345+G", false, false, false},
+		{"WC2300+", false, false, false},
+		{"8F000000+", true, false, true},
+		{"800000+", false, false, false},
+		{"C2000000+", true, false, true},
+		{"F2000000+", true, false, false},
+		{"2W000000+", true, false, false},
+		{"22W00000+", false, false, false},
+	} {
+		if got := IsValidPlusCode(test.Code); got != test.Valid {
+			t.Errorf("IsValidPlusCode(%q) = %v, want %v", test.Code, got, test.Valid)
+		}
+		if got := IsShortPlusCode(test.Code); got != test.Short {
+			t.Errorf("IsShortPlusCode(%q) = %v, want %v", test.Code, got, test.Short)
+		}
+		if got := IsFullPlusCode(test.Code); got != test.Full {
+			t.Errorf("IsFullPlusCode(%q) = %v, want %v", test.Code, got, test.Full)
+		}
+	}
+}
+
+func TestShortenPlusCode(t *testing.T) {
+	for _, test := range []struct {
+		Code     string
+		Lat, Lng float64
+		Short    string
+		Recover  bool
+	}{
+		{"9C3W9QCJ+2VX", 51.3701125, -1.217765625, "+2VX", true},
+		{"9C3W9QCJ+2VX", 51.3708675, -1.217765625, "CJ+2VX", true},
+		{"9C3W9QCJ+2VX", 51.3693575, -1.217765625, "CJ+2VX", true},
+		{"9C3W9QCJ+2VX", 51.3701125, -1.218520625, "CJ+2VX", true},
+		{"9C3W9QCJ+2VX", 51.3701125, -1.217010625, "CJ+2VX", true},
+		{"9C3W9QCJ+2VX", 51.3852125, -1.217765625, "9QCJ+2VX", true},
+		{"9C3W9QCJ+2VX", 51.3550125, -1.217765625, "9QCJ+2VX", true},
+		{"9C3W9QCJ+2VX", 51.3701125, -1.232865625, "9QCJ+2VX", true},
+		{"9C3W9QCJ+2VX", 51.3701125, -1.202665625, "9QCJ+2VX", true},
+		{"8FJFW222+", 42.899, 9.012, "22+", false},
+		{"796RXG22+", 14.95125, -23.5001, "22+", false},
+		{"8FVC2222+22", 47.1, 8.0, "2222+22", true},
+		{"8FVC2222+22", 48.5, 8.0, "8FVC2222+22", true},
+	} {
+		if test.Recover {
+			got, err := ShortenPlusCode(test.Code, LatLng{test.Lat, test.Lng})
+			if err != nil || got != test.Short {
+				t.Errorf("ShortenPlusCode(%q, %v, %v) = %q, %v, want %q", test.Code, test.Lat, test.Lng, got, err, test.Short)
+			}
+		}
+
+		got, err := RecoverPlusCode(test.Short, LatLng{test.Lat, test.Lng})
+		if err != nil || got != test.Code {
+			t.Errorf("RecoverPlusCode(%q, %v, %v) = %q, %v, want %q", test.Short, test.Lat, test.Lng, got, err, test.Code)
+		}
+	}
+
+	if _, err := ShortenPlusCode("8FVC0000+", LatLng{47, 8}); err != errPaddedPlusCode {
+		t.Errorf("ShortenPlusCode() with padded code = %v, want %v", err, errPaddedPlusCode)
+	}
+	if _, err := RecoverPlusCode("not a code", LatLng{}); err != errInvalidPlusCode {
+		t.Errorf("RecoverPlusCode() with invalid code = %v, want %v", err, errInvalidPlusCode)
+	}
+	for _, ref := range []LatLng{{math.NaN(), 8}, {47, math.Inf(1)}, {47, math.Inf(-1)}, {math.Inf(1), 8}} {
+		if _, err := ShortenPlusCode("8FVC2222+22", ref); err != errInvalidRef {
+			t.Errorf("ShortenPlusCode() with reference %v = %v, want %v", ref, err, errInvalidRef)
+		}
+		if _, err := RecoverPlusCode("2222+22", ref); err != errInvalidRef {
+			t.Errorf("RecoverPlusCode() with reference %v = %v, want %v", ref, err, errInvalidRef)
+		}
+	}
+}
+
+func TestNormalizeLongitude(t *testing.T) {
+	for _, test := range []struct {
+		Lng, Want float64
+	}{
+		{0, 0},
+		{179.5, 179.5},
+		{180, -180},
+		{-180, -180},
+		{-181, 179},
+		{540, -180},
+		{-900.5, 179.5},
+	} {
+		if got := normalizeLongitude(test.Lng); got != test.Want {
+			t.Errorf("normalizeLongitude(%v) = %v, want %v", test.Lng, got, test.Want)
+		}
+	}
+}
+
+func TestRecoverPlusCodeEdges(t *testing.T) {
+	for _, test := range []struct {
+		Short    string
+		Lat, Lng float64
+		Want     string
+	}{
+		// The nearest match is across the antimeridian.
+		{"2222+22", 1, 179.9, "62H22222+22"},
+		{"XXXX+XX", 1, -179.9, "6VGXXXXX+XX"},
+		// Moving the match past a pole is not allowed.
+		{"2222+22", 89.6, 0.1, "CFX22222+22"},
+		{"XXXX+XX", -89.6, 0.9, "2F22XXXX+XX"},
+	} {
+		got, err := RecoverPlusCode(test.Short, LatLng{test.Lat, test.Lng})
+		if err != nil || got != test.Want {
+			t.Errorf("RecoverPlusCode(%q, %v, %v) = %q, %v, want %q", test.Short, test.Lat, test.Lng, got, err, test.Want)
+		}
+	}
+}
+
+func TestPlaceDetailsPlusCodeArea(t *testing.T) {
+	for _, test := range []struct {
+		Name  string
+		Place PlaceDetails
+		Want  string
+		Err   error
+	}{
+		{
+			Name:  "global code",
+			Place: PlaceDetails{PlusCode: PlusCode{GlobalCode: "4RRH45R7+G9", CompoundCode: "45R7+G9 Sydney, New South Wales"}},
+			Want:  "4RRH45R7+G9",
+		},
+		{
+			Name: "compound code only",
+			Place: PlaceDetails{
+				PlusCode: PlusCode{CompoundCode: "45R7+G9 Sydney, New South Wales"},
+				Geometry: Geometry{Location: LatLng{Lat: -33.8587, Lng: 151.2140}},
+			},
+			Want: "4RRH45R7+G9",
+		},
+		{
+			Name:  "no plus code",
+			Place: PlaceDetails{},
+			Err:   errMissingPlusCode,
+		},
+		{
+			Name:  "blank compound code",
+			Place: PlaceDetails{PlusCode: PlusCode{CompoundCode: " \t "}},
+			Err:   errInvalidPlusCode,
+		},
+	} {
+		area, err := test.Place.PlusCodeArea()
+		if err != test.Err {
+			t.Errorf("%s: PlaceDetails{}.PlusCodeArea() = %v, want %v", test.Name, err, test.Err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got, _ := EncodePlusCode(area.Center(), area.Len); got != test.Want {
+			t.Errorf("%s: PlaceDetails{}.PlusCodeArea() is the area of %s, want %s", test.Name, got, test.Want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package places
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+var errInvalidPolyline = errors.New("the encoded polyline is malformed")
+
+// polylinePrecision is the number of steps per degree in an encoded polyline.
+const polylinePrecision = 1e5
+
+// EncodePolyline encodes points in the Encoded Polyline Algorithm Format used by the Google Maps APIs, described at https://developers.google.com/maps/documentation/utilities/polylinealgorithm. Coordinates are rounded to five decimal places.
+func EncodePolyline(points []LatLng) string {
+	var b strings.Builder
+	var lat, lng int64
+	for _, p := range points {
+		nextLat := int64(math.Round(p.Lat * polylinePrecision))
+		nextLng := int64(math.Round(p.Lng * polylinePrecision))
+		encodePolylineValue(&b, nextLat-lat)
+		encodePolylineValue(&b, nextLng-lng)
+		lat, lng = nextLat, nextLng
+	}
+	return b.String()
+}
+
+func encodePolylineValue(b *strings.Builder, v int64) {
+	u := uint64(v) << 1
+	if v < 0 {
+		u = ^u
+	}
+	for u >= 0x20 {
+		b.WriteByte(byte(0x20|u&0x1f) + 63)
+		u >>= 5
+	}
+	b.WriteByte(byte(u) + 63)
+}
+
+// DecodePolyline decodes a polyline in the Encoded Polyline Algorithm Format, such as the overview_polyline of a route from the Directions API.
+func DecodePolyline(encoded string) ([]LatLng, error) {
+	var points []LatLng
+	var lat, lng int64
+	for i := 0; i < len(encoded); {
+		var dLat, dLng int64
+		var err error
+		if dLat, i, err = decodePolylineValue(encoded, i); err != nil {
+			return nil, err
+		}
+		if dLng, i, err = decodePolylineValue(encoded, i); err != nil {
+			return nil, err
+		}
+		lat += dLat
+		lng += dLng
+		points = append(points, LatLng{
+			Lat: float64(lat) / polylinePrecision,
+			Lng: float64(lng) / polylinePrecision,
+		})
+	}
+	return points, nil
+}
+
+// decodePolylineValue decodes the value starting at encoded[i], returning it and the index of the next value.
+func decodePolylineValue(encoded string, i int) (int64, int, error) {
+	var u uint64
+	for shift := uint(0); ; shift += 5 {
+		if i >= len(encoded) || shift > 60 {
+			return 0, i, errInvalidPolyline
+		}
+		c := encoded[i]
+		if c < 63 || c > 127 {
+			return 0, i, errInvalidPolyline
+		}
+		i++
+		u |= uint64(c-63) & 0x1f << shift
+		if c-63 < 0x20 {
+			break
+		}
+	}
+	v := int64(u >> 1)
+	if u&1 != 0 {
+		v = ^v
+	}
+	return v, i, nil
+}
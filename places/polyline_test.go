@@ -0,0 +1,47 @@
+package places
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPolyline(t *testing.T) {
+	for _, test := range []struct {
+		Name    string
+		Points  []LatLng
+		Encoded string
+	}{
+		{"empty", nil, ""},
+		{"documentation example", []LatLng{{38.5, -120.2}, {40.7, -120.95}, {43.252, -126.453}}, "_p~iF~ps|U_ulLnnqC_mqNvxq`@"},
+		{"origin", []LatLng{{0, 0}}, "??"},
+		{"repeated point", []LatLng{{-33.86705, 151.19573}, {-33.86705, 151.19573}}, "`tumEilyy[??"},
+	} {
+		if got := EncodePolyline(test.Points); got != test.Encoded {
+			t.Errorf("EncodePolyline() %s = %q, want %q", test.Name, got, test.Encoded)
+		}
+		got, err := DecodePolyline(test.Encoded)
+		if err != nil || !reflect.DeepEqual(got, test.Points) {
+			t.Errorf("DecodePolyline(%q) = %v, %v, want %v", test.Encoded, got, err, test.Points)
+		}
+	}
+}
+
+func TestPolylineRounding(t *testing.T) {
+	got, err := DecodePolyline(EncodePolyline([]LatLng{{51.5007292, -0.1246254}}))
+	if want := []LatLng{{51.50073, -0.12463}}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %v, %v, want %v", got, err, want)
+	}
+}
+
+func TestDecodePolylineInvalid(t *testing.T) {
+	for _, encoded := range []string{
+		"_p~iF",       // a latitude without a longitude
+		"_p~iF~ps|",   // a value cut short
+		"_p~iF~ps| U", // a character outside the alphabet
+		"~~~~~~~~~~~~~~?",
+	} {
+		if _, err := DecodePolyline(encoded); err != errInvalidPolyline {
+			t.Errorf("DecodePolyline(%q) = %v, want %v", encoded, err, errInvalidPolyline)
+		}
+	}
+}
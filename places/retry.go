@@ -0,0 +1,65 @@
+package places
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// A Limiter paces the requests sent by a Service. *rate.Limiter from golang.org/x/time/rate satisfies it.
+type Limiter interface {
+	// Wait blocks until a request may be sent or ctx is done.
+	Wait(ctx context.Context) error
+}
+
+// limiterError is returned when the Limiter refuses to let a request through, e.g. because its context is done. It wraps the limiter's error, which it reports unchanged.
+type limiterError struct {
+	err error
+}
+
+func (e *limiterError) Error() string {
+	return e.err.Error()
+}
+
+func (e *limiterError) Unwrap() error {
+	return e.err
+}
+
+// RetryPolicy describes how a Service retries calls that fail with a transient error: an UNKNOWN status, a 5xx response or a failed connection.
+type RetryPolicy struct {
+	// The maximum number of times a call is sent, including the first. Values below 2 disable retries.
+	MaxAttempts int
+	// How long to wait before the first retry. The wait doubles for each subsequent retry.
+	Backoff time.Duration
+	// The longest wait between two attempts. Zero means no limit.
+	MaxBackoff time.Duration
+}
+
+// retryable reports whether a call that failed with err may succeed if sent again.
+func (p *RetryPolicy) retryable(err error) bool {
+	switch err.(type) {
+	case nil, *breakerError, *json.SyntaxError, *json.UnmarshalTypeError:
+		return false
+	case *apiError:
+		return IsUnknown(err)
+	case *httpError:
+		return IsServerError(err)
+	}
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) && err != errNoAvailableKeys
+}
+
+// backoff returns how long to wait before the given retry, counting from 1.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < retry; i++ {
+		d *= 2
+		if p.MaxBackoff > 0 && d >= p.MaxBackoff {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
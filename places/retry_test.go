@@ -0,0 +1,107 @@
+package places
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for retry, want := range []time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		6: time.Second,
+	} {
+		if retry == 0 {
+			continue
+		}
+		if got := policy.backoff(retry); got != want {
+			t.Errorf("RetryPolicy{}.backoff(%d) = %v, want %v", retry, got, want)
+		}
+	}
+}
+
+func TestRetryPolicyRetryable(t *testing.T) {
+	policy := &RetryPolicy{}
+	for _, test := range []struct {
+		Err  error
+		Want bool
+	}{
+		{nil, false},
+		{&apiError{Status: "UNKNOWN"}, true},
+		{&apiError{Status: "OVER_QUERY_LIMIT"}, false},
+		{&apiError{Status: "INVALID_REQUEST"}, false},
+		{&httpError{StatusCode: 502}, true},
+		{&httpError{StatusCode: 404}, false},
+		{&breakerError{Endpoint: "details"}, false},
+		{errors.New("connection reset by peer"), true},
+		{fmt.Errorf("Get: %w", context.Canceled), false},
+		{errNoAvailableKeys, false},
+	} {
+		if got := policy.retryable(test.Err); got != test.Want {
+			t.Errorf("RetryPolicy{}.retryable(%v) = %v, want %v", test.Err, got, test.Want)
+		}
+	}
+}
+
+type countingLimiter struct {
+	waits int
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.waits++
+	return nil
+}
+
+func TestServiceRetry(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			fmt.Fprint(w, `{"status": "UNKNOWN"}`)
+		default:
+			fmt.Fprint(w, `{"status": "OK", "result": {"name": "Google"}}`)
+		}
+	}))
+	defer ts.Close()
+
+	limiter := &countingLimiter{}
+	metrics := NewMetrics()
+	service := NewService(http.DefaultClient, "key",
+		WithBaseURL(ts.URL),
+		WithLimiter(limiter),
+		WithCollector(metrics),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}),
+	)
+
+	resp, err := service.Details("place").Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Result.Name != "Google" {
+		t.Errorf("DetailsCall{}.Do().Result.Name = %q, want Google", resp.Result.Name)
+	}
+	if requests != 3 || limiter.waits != 3 {
+		t.Errorf("requests, limiter waits = %d, %d, want 3, 3", requests, limiter.waits)
+	}
+
+	requests = 0
+	service = NewService(http.DefaultClient, "key",
+		WithBaseURL(ts.URL),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond}),
+	)
+	if _, err := service.Details("place").Do(); !IsUnknown(err) {
+		t.Errorf("DetailsCall{}.Do() after exhausting retries = %v, want UNKNOWN", err)
+	}
+}
@@ -0,0 +1,74 @@
+package places
+
+import (
+	"context"
+	"time"
+)
+
+// defaultCollectInterval is how long a ReviewCollector waits between rounds unless Interval is set.
+const defaultCollectInterval = 24 * time.Hour
+
+// ReviewCollector fetches the reviews of places into a ReviewStore. Each collection sends one Details request per language, since the API returns different reviews depending on the language requested.
+type ReviewCollector struct {
+	service *Service
+	store   *ReviewStore
+
+	// Languages are the languages to request reviews in. Empty requests them once in the service's language.
+	Languages []string
+	// Interval is how long Run waits between collecting the reviews of every place. Zero means once a day.
+	Interval time.Duration
+	// OnError, if set, is called with the errors from collecting the reviews of a place. Run carries on after an error.
+	OnError func(placeID string, err error)
+}
+
+// ReviewCollector returns a ReviewCollector that fetches reviews with the service into store.
+func (p *Service) ReviewCollector(store *ReviewStore) *ReviewCollector {
+	return &ReviewCollector{service: p, store: store}
+}
+
+// Collect fetches the reviews of the place with the given ID in every language and adds them to the store, returning how many were new. If a request fails, the reviews from the others are still stored and the first error is returned.
+func (c *ReviewCollector) Collect(ctx context.Context, placeID string) (int, error) {
+	languages := c.Languages
+	if len(languages) == 0 {
+		languages = []string{""}
+	}
+
+	added := 0
+	var firstErr error
+	for _, language := range languages {
+		call := c.service.Details(placeID)
+		call.Language = language
+		resp, err := call.Context(ctx).Do()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		added += c.store.Add(placeID, resp.Result.Reviews, time.Now())
+	}
+	return added, firstErr
+}
+
+// Run collects the reviews of the places with the given IDs once per Interval until ctx ends, then returns its error.
+func (c *ReviewCollector) Run(ctx context.Context, placeIDs ...string) error {
+	interval := c.Interval
+	if interval <= 0 {
+		interval = defaultCollectInterval
+	}
+	for {
+		for _, id := range placeIDs {
+			if _, err := c.Collect(ctx, id); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				if c.OnError != nil {
+					c.OnError(id, err)
+				}
+			}
+		}
+		if err := sleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
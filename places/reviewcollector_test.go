@@ -0,0 +1,44 @@
+package places
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReviewCollectorCollect(t *testing.T) {
+	reviews := map[string]string{
+		"en": `[{"author_name": "Ann", "time": 100, "text": "Great", "rating": 5, "language": "en"}, {"author_name": "Bob", "time": 200, "text": "Fine", "rating": 3, "language": "en"}]`,
+		"fr": `[{"author_name": "Ann", "time": 100, "text": "Great", "rating": 5, "language": "en"}, {"author_name": "Luc", "time": 300, "text": "Bien", "rating": 4, "language": "fr"}]`,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		list, ok := reviews[r.URL.Query().Get("language")]
+		if !ok {
+			fmt.Fprint(w, `{"status": "INVALID_REQUEST"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status": "OK", "result": {"place_id": "a", "reviews": %s}}`, list)
+	}))
+	defer ts.Close()
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL))
+
+	store := NewReviewStore()
+	c := service.ReviewCollector(store)
+	c.Languages = []string{"en", "fr"}
+	if n, err := c.Collect(context.Background(), "a"); err != nil || n != 3 {
+		t.Errorf("Collect() = %d, %v, want 3 new reviews", n, err)
+	}
+	if n, err := c.Collect(context.Background(), "a"); err != nil || n != 0 {
+		t.Errorf("Collect() again = %d, %v, want no new reviews", n, err)
+	}
+	if stats := store.Stats("a"); stats.Count != 3 || stats.Languages["fr"] != 1 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+
+	c.Languages = []string{"de", "en"}
+	if n, err := c.Collect(context.Background(), "b"); !IsInvalidRequest(err) || n != 2 {
+		t.Errorf("Collect() with a failing language = %d, %v, want 2 new reviews and INVALID_REQUEST", n, err)
+	}
+}
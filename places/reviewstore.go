@@ -0,0 +1,223 @@
+package places
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// StoredReview is a review kept by a ReviewStore, with when it was collected.
+type StoredReview struct {
+	Review
+	// FirstSeen is when the review was first collected.
+	FirstSeen time.Time `json:"first_seen"`
+	// LastSeen is when the review was most recently returned by the API.
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// ReviewStore accumulates the reviews of places. The API returns at most five reviews for a place and they change over time, so collecting them repeatedly builds up a history. Reviews are identified by their author, time and text, so the same review is stored once however often it is collected.
+//
+// A ReviewStore is safe for concurrent use.
+type ReviewStore struct {
+	mu     sync.Mutex
+	places map[string]map[string]*StoredReview
+}
+
+// NewReviewStore returns an empty ReviewStore.
+func NewReviewStore() *ReviewStore {
+	return &ReviewStore{places: map[string]map[string]*StoredReview{}}
+}
+
+// reviewKey identifies a review by a hash of its author, time and text.
+func reviewKey(r *Review) string {
+	h := sha256.New()
+	for _, s := range []string{r.AuthorName, strconv.Itoa(r.Time), r.Text} {
+		io.WriteString(h, s)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// Add stores the reviews of the place with the given ID, seen at the given time, and returns how many of them were not already stored.
+func (s *ReviewStore) Add(placeID string, reviews []*Review, seen time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := s.places[placeID]
+	if stored == nil {
+		stored = map[string]*StoredReview{}
+		s.places[placeID] = stored
+	}
+	added := 0
+	for _, r := range reviews {
+		if r == nil {
+			continue
+		}
+		key := reviewKey(r)
+		if have, ok := stored[key]; ok {
+			if seen.After(have.LastSeen) {
+				have.LastSeen = seen
+			}
+			continue
+		}
+		stored[key] = &StoredReview{Review: *r, FirstSeen: seen, LastSeen: seen}
+		added++
+	}
+	return added
+}
+
+// Places returns the IDs of the places with stored reviews, sorted.
+func (s *ReviewStore) Places() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.places))
+	for id, reviews := range s.places {
+		if len(reviews) > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Reviews returns the stored reviews of the place with the given ID, newest first.
+func (s *ReviewStore) Reviews(placeID string) []StoredReview {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reviews := make([]StoredReview, 0, len(s.places[placeID]))
+	for _, r := range s.places[placeID] {
+		reviews = append(reviews, *r)
+	}
+	sortReviews(reviews)
+	return reviews
+}
+
+// sortReviews orders reviews newest first, breaking ties by author and text so the order is stable.
+func sortReviews(reviews []StoredReview) {
+	sort.Slice(reviews, func(i, j int) bool {
+		a, b := reviews[i], reviews[j]
+		if a.Time != b.Time {
+			return a.Time > b.Time
+		}
+		if a.AuthorName != b.AuthorName {
+			return a.AuthorName < b.AuthorName
+		}
+		return a.Text < b.Text
+	})
+}
+
+// Save writes every stored review to w as JSON, in a form Load reads.
+func (s *ReviewStore) Save(w io.Writer) error {
+	s.mu.Lock()
+	all := make(map[string][]StoredReview, len(s.places))
+	for id, stored := range s.places {
+		reviews := make([]StoredReview, 0, len(stored))
+		for _, r := range stored {
+			reviews = append(reviews, *r)
+		}
+		sortReviews(reviews)
+		all[id] = reviews
+	}
+	s.mu.Unlock()
+	return json.NewEncoder(w).Encode(all)
+}
+
+// Load adds the reviews saved by Save to the store. Reviews that are already stored keep the earliest FirstSeen and latest LastSeen of the two.
+func (s *ReviewStore) Load(r io.Reader) error {
+	var all map[string][]StoredReview
+	if err := json.NewDecoder(r).Decode(&all); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, reviews := range all {
+		stored := s.places[id]
+		if stored == nil {
+			stored = map[string]*StoredReview{}
+			s.places[id] = stored
+		}
+		for i := range reviews {
+			r := reviews[i]
+			key := reviewKey(&r.Review)
+			have, ok := stored[key]
+			if !ok {
+				stored[key] = &r
+				continue
+			}
+			if r.FirstSeen.Before(have.FirstSeen) {
+				have.FirstSeen = r.FirstSeen
+			}
+			if r.LastSeen.After(have.LastSeen) {
+				have.LastSeen = r.LastSeen
+			}
+		}
+	}
+	return nil
+}
+
+// ReviewStats summarises a set of reviews.
+type ReviewStats struct {
+	// Count is the number of reviews.
+	Count int
+	// Average is the mean rating of the reviews, or 0 if there are none.
+	Average float64
+	// Ratings counts the reviews with each rating: Ratings[5] is the number of five-star reviews. Ratings[0] counts reviews without a rating.
+	Ratings [6]int
+	// Aspects is the mean rating, from 0 to 3, given to each aspect, e.g. "food" or "service", by the reviews that rate it.
+	Aspects map[string]float64
+	// Languages counts the reviews in each language. Reviews without a language are counted under "".
+	Languages map[string]int
+	// Oldest and Newest are the times the oldest and newest reviews were written.
+	Oldest, Newest time.Time
+}
+
+// Stats returns statistics over the stored reviews of the place with the given ID.
+func (s *ReviewStore) Stats(placeID string) ReviewStats {
+	return ReviewStatistics(s.Reviews(placeID))
+}
+
+// ReviewStatistics returns statistics over reviews.
+func ReviewStatistics(reviews []StoredReview) ReviewStats {
+	stats := ReviewStats{
+		Aspects:   map[string]float64{},
+		Languages: map[string]int{},
+	}
+	aspectCounts := map[string]int{}
+	rated, total := 0, 0
+	for _, r := range reviews {
+		stats.Count++
+		stats.Languages[r.Language]++
+		if r.Rating >= 1 && r.Rating <= 5 {
+			stats.Ratings[r.Rating]++
+			rated++
+			total += r.Rating
+		} else {
+			stats.Ratings[0]++
+		}
+		for _, a := range r.Aspects {
+			stats.Aspects[a.Type] += float64(a.Rating)
+			aspectCounts[a.Type]++
+		}
+
+		written := time.Unix(int64(r.Time), 0).UTC()
+		if stats.Oldest.IsZero() || written.Before(stats.Oldest) {
+			stats.Oldest = written
+		}
+		if written.After(stats.Newest) {
+			stats.Newest = written
+		}
+	}
+	if rated > 0 {
+		stats.Average = float64(total) / float64(rated)
+	}
+	for aspect, n := range aspectCounts {
+		stats.Aspects[aspect] /= float64(n)
+	}
+	return stats
+}
@@ -0,0 +1,95 @@
+package places
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestReviewStoreAdd(t *testing.T) {
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	store := NewReviewStore()
+
+	first := []*Review{
+		{AuthorName: "Ann", Time: 100, Text: "Great", Rating: 5},
+		{AuthorName: "Bob", Time: 200, Text: "Fine", Rating: 3},
+	}
+	second := []*Review{
+		{AuthorName: "Bob", Time: 200, Text: "Fine", Rating: 3},
+		{AuthorName: "Bob", Time: 200, Text: "Edited", Rating: 2},
+		{AuthorName: "Cat", Time: 150, Text: "Good", Rating: 4},
+		nil,
+	}
+	if n := store.Add("a", first, monday); n != 2 {
+		t.Errorf("Add() = %d, want 2", n)
+	}
+	if n := store.Add("a", second, tuesday); n != 2 {
+		t.Errorf("Add() with one repeated review = %d, want 2", n)
+	}
+	store.Add("b", nil, tuesday)
+
+	var got []string
+	for _, r := range store.Reviews("a") {
+		got = append(got, fmt.Sprintf("%s/%s %s-%s", r.AuthorName, r.Text, r.FirstSeen.Weekday(), r.LastSeen.Weekday()))
+	}
+	want := "[Bob/Edited Tuesday-Tuesday Bob/Fine Monday-Tuesday Cat/Good Tuesday-Tuesday Ann/Great Monday-Monday]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("Reviews() = %v, want %v", got, want)
+	}
+	if got := fmt.Sprint(store.Places()); got != "[a]" {
+		t.Errorf("Places() = %s, want [a]", got)
+	}
+}
+
+func TestReviewStoreSaveLoad(t *testing.T) {
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	saved := NewReviewStore()
+	saved.Add("a", []*Review{{AuthorName: "Ann", Time: 100, Text: "Great"}}, monday)
+
+	var buf bytes.Buffer
+	if err := saved.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewReviewStore()
+	loaded.Add("a", []*Review{{AuthorName: "Ann", Time: 100, Text: "Great"}}, monday.AddDate(0, 0, 7))
+	if err := loaded.Load(&buf); err != nil {
+		t.Fatal(err)
+	}
+	reviews := loaded.Reviews("a")
+	if len(reviews) != 1 || !reviews[0].FirstSeen.Equal(monday) || !reviews[0].LastSeen.Equal(monday.AddDate(0, 0, 7)) {
+		t.Errorf("Reviews() after Load() = %+v, want one review first seen on the saved date", reviews)
+	}
+}
+
+func TestReviewStatistics(t *testing.T) {
+	reviews := []StoredReview{
+		{Review: Review{Rating: 5, Language: "en", Time: 1000, Aspects: []AspectRating{{Type: "food", Rating: 3}, {Type: "service", Rating: 2}}}},
+		{Review: Review{Rating: 4, Language: "en", Time: 3000, Aspects: []AspectRating{{Type: "food", Rating: 2}}}},
+		{Review: Review{Rating: 1, Language: "fr", Time: 2000}},
+		{Review: Review{Language: "fr", Time: 500}},
+	}
+	stats := ReviewStatistics(reviews)
+	for _, test := range []struct {
+		Name      string
+		Got, Want interface{}
+	}{
+		{"count", stats.Count, 4},
+		{"average", stats.Average, 10.0 / 3},
+		{"ratings", stats.Ratings, [6]int{1, 1, 0, 0, 1, 1}},
+		{"aspects", fmt.Sprint(stats.Aspects), "map[food:2.5 service:2]"},
+		{"languages", fmt.Sprint(stats.Languages), "map[en:2 fr:2]"},
+		{"oldest", stats.Oldest.Unix(), int64(500)},
+		{"newest", stats.Newest.Unix(), int64(3000)},
+	} {
+		if test.Got != test.Want {
+			t.Errorf("%s = %v, want %v", test.Name, test.Got, test.Want)
+		}
+	}
+
+	if empty := ReviewStatistics(nil); empty.Count != 0 || empty.Average != 0 || !empty.Newest.IsZero() {
+		t.Errorf("ReviewStatistics(nil) = %+v", empty)
+	}
+}
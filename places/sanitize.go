@@ -0,0 +1,292 @@
+package places
+
+import (
+	"html"
+	"net/url"
+	"strings"
+)
+
+// allowedTags are the elements kept by SanitizeHTML. Links are the only element that keeps an attribute.
+var allowedTags = map[string]bool{
+	"a": true, "b": true, "strong": true, "i": true, "em": true, "u": true, "br": true, "p": true, "span": true,
+}
+
+// rawTextTags are the elements whose content is not markup, and which SanitizeHTML and HTMLToText drop together with their content.
+var rawTextTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "textarea": true, "title": true, "xmp": true,
+	"noscript": true, "noembed": true, "noframes": true, "template": true, "plaintext": true,
+}
+
+// blockTags are the elements that HTMLToText ends with a line break.
+var blockTags = map[string]bool{
+	"p": true, "div": true, "li": true, "tr": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+// safeSchemes are the URL schemes allowed in links.
+var safeSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// SanitizeHTML returns s, such as an attribution or the text of a review, reduced to markup that is safe to include in a web page: links, line breaks, paragraphs and simple text formatting. Links keep only an http, https or mailto href and are given rel="nofollow noopener". Other elements are removed but their text is kept, except for elements like scripts and styles, which are removed entirely. Text is escaped and every element left open is closed.
+func SanitizeHTML(s string) string {
+	var b strings.Builder
+	var open []string
+	tokenizeHTML(s, func(t htmlToken) {
+		switch t.kind {
+		case textToken:
+			b.WriteString(html.EscapeString(html.UnescapeString(t.data)))
+		case startTagToken:
+			if !allowedTags[t.name] {
+				return
+			}
+			switch t.name {
+			case "br":
+				b.WriteString("<br>")
+				return
+			case "a":
+				b.WriteString("<a")
+				if href, ok := safeURL(t.attr("href")); ok {
+					b.WriteString(` href="` + html.EscapeString(href) + `"`)
+				}
+				b.WriteString(` rel="nofollow noopener">`)
+			default:
+				b.WriteString("<" + t.name + ">")
+			}
+			// Only void elements such as br can be self-closing, so a "/>" on any other tag still leaves it open.
+			open = append(open, t.name)
+		case endTagToken:
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != t.name {
+					continue
+				}
+				// Close the element and any left open inside it.
+				for j := len(open) - 1; j >= i; j-- {
+					b.WriteString("</" + open[j] + ">")
+				}
+				open = open[:i]
+				return
+			}
+		}
+	})
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString("</" + open[i] + ">")
+	}
+	return b.String()
+}
+
+// HTMLToText returns the text of s, such as an attribution or the text of a review, without any markup and with entities decoded. Line breaks and the ends of paragraphs become newlines, other runs of white space become a single space, and the result is trimmed.
+func HTMLToText(s string) string {
+	var b strings.Builder
+	tokenizeHTML(s, func(t htmlToken) {
+		switch {
+		case t.kind == textToken:
+			b.WriteString(html.UnescapeString(t.data))
+		case t.name == "br", t.kind == endTagToken && blockTags[t.name]:
+			b.WriteByte('\n')
+		}
+	})
+	return normalizeSpace(b.String())
+}
+
+// normalizeSpace collapses runs of white space other than newlines into a single space, trims every line and removes blank lines at the start and end and repeated blank lines.
+func normalizeSpace(s string) string {
+	lines := strings.Split(s, "\n")
+	var kept []string
+	for _, line := range lines {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" && (len(kept) == 0 || kept[len(kept)-1] == "") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	for len(kept) > 0 && kept[len(kept)-1] == "" {
+		kept = kept[:len(kept)-1]
+	}
+	return strings.Join(kept, "\n")
+}
+
+// safeURL returns the value of an href attribute if it is an absolute URL with a safe scheme.
+func safeURL(raw string) (string, bool) {
+	// Browsers ignore control characters and white space inside a URL, so "java\tscript:" is a javascript URL.
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, html.UnescapeString(raw))
+	u, err := url.Parse(cleaned)
+	if err != nil || !safeSchemes[strings.ToLower(u.Scheme)] {
+		return "", false
+	}
+	return cleaned, true
+}
+
+type htmlTokenKind int
+
+const (
+	textToken htmlTokenKind = iota
+	startTagToken
+	endTagToken
+)
+
+type htmlAttr struct {
+	name, value string
+}
+
+// htmlToken is a piece of an HTML fragment: text, still containing entities, or a start or end tag with a lower-case name.
+type htmlToken struct {
+	kind  htmlTokenKind
+	data  string
+	name  string
+	attrs []htmlAttr
+}
+
+// attr returns the value of the attribute with the given name, still containing entities.
+func (t htmlToken) attr(name string) string {
+	for _, a := range t.attrs {
+		if a.name == name {
+			return a.value
+		}
+	}
+	return ""
+}
+
+// tokenizeHTML splits an HTML fragment into tokens, calling fn for each. Comments, doctypes and processing instructions are skipped, as are raw text elements such as scripts together with their content. A '<' that does not start a tag is text, and an unterminated tag is dropped with the rest of the input, as a browser would not render it either.
+func tokenizeHTML(s string, fn func(htmlToken)) {
+	for len(s) > 0 {
+		lt := strings.IndexByte(s, '<')
+		if lt < 0 {
+			fn(htmlToken{kind: textToken, data: s})
+			return
+		}
+		if lt > 0 {
+			fn(htmlToken{kind: textToken, data: s[:lt]})
+			s = s[lt:]
+		}
+
+		switch {
+		case strings.HasPrefix(s, "<!--"):
+			end := strings.Index(s[4:], "-->")
+			if end < 0 {
+				return
+			}
+			s = s[4+end+3:]
+		case strings.HasPrefix(s, "<!"), strings.HasPrefix(s, "<?"):
+			end := strings.IndexByte(s, '>')
+			if end < 0 {
+				return
+			}
+			s = s[end+1:]
+		case len(s) > 1 && isASCIILetter(s[1]), len(s) > 2 && s[1] == '/' && isASCIILetter(s[2]):
+			t, rest, ok := parseTag(s)
+			if !ok {
+				return
+			}
+			s = rest
+			if t.kind == startTagToken && rawTextTags[t.name] {
+				s = skipRawText(s, t.name)
+				continue
+			}
+			fn(t)
+		default:
+			fn(htmlToken{kind: textToken, data: "<"})
+			s = s[1:]
+		}
+	}
+}
+
+// parseTag parses the tag at the start of s, returning it and the input after it.
+func parseTag(s string) (htmlToken, string, bool) {
+	t := htmlToken{kind: startTagToken}
+	i := 1
+	if s[i] == '/' {
+		t.kind = endTagToken
+		i++
+	}
+	start := i
+	for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '/' && s[i] != '>' {
+		i++
+	}
+	t.name = strings.ToLower(s[start:i])
+
+	for {
+		for i < len(s) && (isHTMLSpace(s[i]) || s[i] == '/') {
+			i++
+		}
+		if i >= len(s) {
+			return t, "", false
+		}
+		if s[i] == '>' {
+			return t, s[i+1:], true
+		}
+
+		start := i
+		for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '=' && s[i] != '>' && s[i] != '/' {
+			i++
+		}
+		attr := htmlAttr{name: strings.ToLower(s[start:i])}
+		for i < len(s) && isHTMLSpace(s[i]) {
+			i++
+		}
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isHTMLSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				end := strings.IndexByte(s[i+1:], s[i])
+				if end < 0 {
+					return t, "", false
+				}
+				attr.value = s[i+1 : i+1+end]
+				i += end + 2
+			} else {
+				start := i
+				for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				attr.value = s[start:i]
+			}
+		}
+		t.attrs = append(t.attrs, attr)
+	}
+}
+
+// skipRawText returns the input after the end tag of the raw text element with the given name, or nothing if it is not closed.
+func skipRawText(s, name string) string {
+	for i := 0; i+2+len(name) <= len(s); i++ {
+		if s[i] != '<' || s[i+1] != '/' || !asciiEqualFold(s[i+2:i+2+len(name)], name) {
+			continue
+		}
+		end := i + 2 + len(name)
+		if end < len(s) && !isHTMLSpace(s[end]) && s[end] != '>' && s[end] != '/' {
+			continue
+		}
+		gt := strings.IndexByte(s[end:], '>')
+		if gt < 0 {
+			return ""
+		}
+		return s[end+gt+1:]
+	}
+	return ""
+}
+
+// asciiEqualFold reports whether s and lower are equal, ignoring the case of ASCII letters in s.
+func asciiEqualFold(s, lower string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c != lower[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isASCIILetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -0,0 +1,64 @@
+package places
+
+import "testing"
+
+func TestSanitizeHTML(t *testing.T) {
+	for _, test := range []struct {
+		Name, In, Want string
+	}{
+		{"plain text", "Listings by Yellow Pages", "Listings by Yellow Pages"},
+		{"attribution link", `<a href="https://maps.google.com/maps/contrib/1">Ann</a>`, `<a href="https://maps.google.com/maps/contrib/1" rel="nofollow noopener">Ann</a>`},
+		{"entities", "Fish &amp; Chips &lt;3 &copy;", "Fish &amp; Chips &lt;3 ©"},
+		{"bare ampersand and angle", "a & b < c > d", "a &amp; b &lt; c &gt; d"},
+		{"formatting", "<B>Great</B> <em>food</em><br/>Will return", "<b>Great</b> <em>food</em><br>Will return"},
+		{"script", `Nice<script>alert("x")</script> place`, "Nice place"},
+		{"script with odd case and spacing", `<SCRIPT type="text/javascript">alert(1)</script >ok`, "ok"},
+		{"script end tag in a string", `<script>var s = "</scriptx>"; alert(1)</script>ok`, "ok"},
+		{"unclosed script", `ok<script>alert(1)`, "ok"},
+		{"style", "<style>body{display:none}</style>text", "text"},
+		{"event handler", `<img src=x onerror="alert(1)">pic`, "pic"},
+		{"attributes dropped", `<b onclick="alert(1)" style="color:red">bold</b>`, "<b>bold</b>"},
+		{"javascript link", `<a href="javascript:alert(1)">x</a>`, `<a rel="nofollow noopener">x</a>`},
+		{"obfuscated javascript link", `<a href="java&#x09;script:alert(1)">x</a>`, `<a rel="nofollow noopener">x</a>`},
+		{"javascript link with control characters", "<a href=\" \x01javascript:alert(1)\">x</a>", `<a rel="nofollow noopener">x</a>`},
+		{"data link", `<a href="data:text/html;base64,PHNjcmlwdD4=">x</a>`, `<a rel="nofollow noopener">x</a>`},
+		{"relative link", `<a href="/maps">x</a>`, `<a rel="nofollow noopener">x</a>`},
+		{"quote in link", `<a href='https://example.com/"onmouseover="alert(1)'>x</a>`, `<a href="https://example.com/&#34;onmouseover=&#34;alert(1)" rel="nofollow noopener">x</a>`},
+		{"unquoted attribute", `<a href=https://example.com title=x>x</a>`, `<a href="https://example.com" rel="nofollow noopener">x</a>`},
+		{"comment", "a<!-- <script>alert(1)</script> -->b", "ab"},
+		{"unterminated comment", "a<!-- b", "a"},
+		{"unterminated tag", `a<a href="https://example.com`, "a"},
+		{"iframe", `<iframe src="https://evil.example"></iframe>x`, "x"},
+		{"unclosed elements", "<b><i>text", "<b><i>text</i></b>"},
+		{"misnested elements", "<b><i>text</b> more</i>", "<b><i>text</i></b> more"},
+		{"stray end tag", "text</div></b>", "text"},
+		{"doctype", "<!DOCTYPE html>text", "text"},
+		{"svg", `<svg><script>alert(1)</script><a xlink:href="javascript:alert(1)">x</a></svg>`, `<a rel="nofollow noopener">x</a>`},
+		{"less than not a tag", "3 <4", "3 &lt;4"},
+		{"self-closing link", `<a href="https://evil.example"/>rest`, `<a href="https://evil.example" rel="nofollow noopener">rest</a>`},
+		{"self-closing bold", "<b/>x", "<b>x</b>"},
+		{"self-closing line break", "a<br/>b<br />c", "a<br>b<br>c"},
+	} {
+		if got := SanitizeHTML(test.In); got != test.Want {
+			t.Errorf("SanitizeHTML() %s = %q, want %q", test.Name, got, test.Want)
+		}
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	for _, test := range []struct {
+		Name, In, Want string
+	}{
+		{"link", `<a href="https://maps.google.com/maps/contrib/1">Ann</a>`, "Ann"},
+		{"entities", "Fish &amp; Chips &#8212; &quot;great&quot;", `Fish & Chips — "great"`},
+		{"line breaks", "Good food.<br>Slow service.<BR/>  Would   return", "Good food.\nSlow service.\nWould return"},
+		{"paragraphs", "<p>One</p><p>Two</p>\n\n\n<p>Three</p>", "One\nTwo\n\nThree"},
+		{"script", `Hi<script>document.write("<b>x</b>")</script> there`, "Hi there"},
+		{"entity that looks like a tag", "&lt;script&gt;alert(1)&lt;/script&gt;", "<script>alert(1)</script>"},
+		{"surrounding space", "  \n text \n ", "text"},
+	} {
+		if got := HTMLToText(test.In); got != test.Want {
+			t.Errorf("HTMLToText() %s = %q, want %q", test.Name, got, test.Want)
+		}
+	}
+}
@@ -1,10 +1,9 @@
 package places
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 )
@@ -24,21 +23,21 @@ const (
 // Nearby lets you search for places within a specified area. You can refine your search request by supplying keywords or specifying the type of place you are searching for.
 func (p *Service) Nearby(lat, lng float64) *NearbyCall {
 	return &NearbyCall{
-		service: p,
-		lat:     lat,
-		lng:     lng,
+		service:  p,
+		location: NewLocation(lat, lng),
 	}
 }
 
 type NearbyCall struct {
 	service *Service
+	ctx     context.Context
 
 	// The latitude/longitude around which to retrieve place information
-	lat, lng float64
+	location Location
 
 	// A term to be matched against all content that Google has indexed for this place, including but not limited to name, type, and address, as well as customer reviews and other third-party content.
 	Keyword string
-	// The language code, indicating in which language the results should be returned, if possible.
+	// The language code, indicating in which language the results should be returned, if possible. Defaults to the service's language.
 	Language string
 	// Restricts results to only those places within the specified price level.
 	MinPrice, MaxPrice *PriceLevel
@@ -58,73 +57,81 @@ type NearbyCall struct {
 	PageToken string
 }
 
+// validate reports every invalid parameter of the call as ValidationErrors. When a page token is set the parameters it replaces need not be given, but those that are must still be valid.
 func (n *NearbyCall) validate() error {
-	if n.PageToken != "" {
-		return nil
-	}
+	paging := n.PageToken != ""
+
+	var v validator
+	v.check("location", n.location.validate())
+	v.radius(n.Radius)
 	switch n.RankBy {
 	case RankByDefault, RankByProminence:
-		if n.Radius == 0 {
-			return errInvalidByProminence
+		if n.Radius == 0 && !paging {
+			v.check("radius", errInvalidByProminence)
 		}
 	case RankByDistance:
-		if n.Type == "" && n.Name == "" && n.Keyword == "" {
-			return errInvalidByDistance
+		if n.Radius != 0 {
+			v.check("radius", errRadiusWithDistance)
 		}
-	}
-	return nil
+		if n.Type == "" && n.Name == "" && n.Keyword == "" && !paging {
+			v.check("rankby", errInvalidByDistance)
+		}
+	default:
+		v.check("rankby", errInvalidRankBy)
+	}
+	v.prices(n.MinPrice, n.MaxPrice)
+	v.text("keyword", n.Keyword)
+	v.text("name", n.Name)
+	v.featureType(n.Type)
+	v.check("language", validateLanguage(n.service.languageOr(n.Language)))
+	return v.err()
 }
 
-func (n *NearbyCall) Do() (*SearchResponse, error) {
+// Context sets the context used by Do. Cancelling it aborts the request.
+func (n *NearbyCall) Context(ctx context.Context) *NearbyCall {
+	n.ctx = ctx
+	return n
+}
+
+// Request validates the call and returns the request Do would send, without credentials. Use Service.Authorize to add them before sending it.
+func (n *NearbyCall) Request(ctx context.Context) (*http.Request, error) {
 	if err := n.validate(); err != nil {
 		return nil, err
 	}
+	return n.service.request(callContext(ctx), "nearbysearch", n.query())
+}
 
-	searchURL := baseURL + "/nearbysearch/json?" + n.query()
+func (n *NearbyCall) Do() (*SearchResponse, error) {
+	return n.do(callContext(n.ctx))
+}
 
-	resp, err := n.service.client.Get(searchURL)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
+func (n *NearbyCall) do(ctx context.Context) (*SearchResponse, error) {
+	if err := n.validate(); err != nil {
 		return nil, err
 	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("bad resp %d: %s", resp.StatusCode, body)
-	}
 
 	data := &SearchResponse{}
-	if err := json.Unmarshal(body, data); err != nil {
+	if err := n.service.get(ctx, "nearbysearch", n.query(), data); err != nil {
 		return nil, err
 	}
 
-	if data.Status != "OK" {
-		return nil, &apiError{
-			Status:  data.Status,
-			Message: data.ErrorMessage,
-		}
-	}
-
 	return data, nil
 }
 
-func (r *NearbyCall) query() string {
+func (r *NearbyCall) query() url.Values {
 	query := make(url.Values)
-	query.Add("key", r.service.key)
-	query.Add("location", fmt.Sprintf("%f,%f", r.lat, r.lng))
+	query.Add("location", r.location.String())
 
 	if r.PageToken != "" {
 		query.Add("pagetoken", r.PageToken)
-		return query.Encode()
+		return query
 	}
 
 	if r.Keyword != "" {
 		query.Add("keyword", r.Keyword)
 	}
-	if r.Language != "" {
-		query.Add("language", r.Language)
+	if language := r.service.languageOr(r.Language); language != "" {
+		query.Add("language", language)
 	}
 	if r.MinPrice != nil {
 		query.Add("minprice", fmt.Sprint(*r.MinPrice))
@@ -151,7 +158,7 @@ func (r *NearbyCall) query() string {
 		query.Add("type", string(r.Type))
 	}
 
-	return query.Encode()
+	return query
 }
 
 // TextSearch returns information about a set of places based on a string.
@@ -165,14 +172,17 @@ func (p *Service) TextSearch(query string) *TextSearchCall {
 // TextSearchCall represents a call to the Text Search API.
 type TextSearchCall struct {
 	service *Service
+	ctx     context.Context
 
 	// The text string on which to search, for example: "restaurant". The Google Places service will return candidate matches based on this string and order the results based on their perceived relevance.
 	queryStr string
 
-	// The latitude/longitude around which to retrieve place information
-	lat, lng float64
-	// The language code, indicating in which language the results should be returned, if possible.
+	// The latitude/longitude around which to bias results. A Radius is required when it is set.
+	Location Location
+	// The language code, indicating in which language the results should be returned, if possible. Defaults to the service's language.
 	Language string
+	// The region code, specified as a ccTLD ("top-level domain") two-character value, used to bias results towards a region. Defaults to the service's region.
+	Region string
 	// Restricts results to only those places within the specified price level.
 	MinPrice, MaxPrice *PriceLevel
 	// Returns only those places that are open for business at the time the query is sent. Places that do not specify opening hours in the Google Places database will not be returned if you include this parameter in your query.
@@ -187,82 +197,80 @@ type TextSearchCall struct {
 	PageToken string
 }
 
+// validate reports every invalid parameter of the call as ValidationErrors. When a page token is set the parameters it replaces need not be given, but those that are must still be valid.
 func (t *TextSearchCall) validate() error {
-	if t.PageToken != "" {
-		return nil
-	}
+	paging := t.PageToken != ""
 
-	if t.queryStr == "" {
-		return errEmptyQuery
+	var v validator
+	if t.queryStr == "" && !paging {
+		v.check("query", errEmptyQuery)
 	}
+	v.text("query", t.queryStr)
 
-	if t.lat != 0 || t.lng != 0 {
-		if t.Radius == 0 {
-			return errMissingRadius
-		}
+	v.check("location", t.Location.validate())
+	if t.Location.IsSet() && t.Radius == 0 && !paging {
+		v.check("radius", errMissingRadius)
 	}
+	v.radius(t.Radius)
 
-	if t.Radius > maximumRadius {
-		return errRadiusIsTooGreat
-	}
+	v.prices(t.MinPrice, t.MaxPrice)
+	v.featureType(t.Type)
+	v.check("language", validateLanguage(t.service.languageOr(t.Language)))
+	return v.err()
+}
 
-	return nil
+// Context sets the context used by Do. Cancelling it aborts the request.
+func (t *TextSearchCall) Context(ctx context.Context) *TextSearchCall {
+	t.ctx = ctx
+	return t
 }
 
-// Do performs the TextSearchCall request.
-func (t *TextSearchCall) Do() (*SearchResponse, error) {
+// Request validates the call and returns the request Do would send, without credentials. Use Service.Authorize to add them before sending it.
+func (t *TextSearchCall) Request(ctx context.Context) (*http.Request, error) {
 	if err := t.validate(); err != nil {
 		return nil, err
 	}
+	return t.service.request(callContext(ctx), "textsearch", t.query())
+}
 
-	searchURL := baseURL + "/textsearch/json?" + t.query()
-	resp, err := t.service.client.Get(searchURL)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+// Do performs the TextSearchCall request.
+func (t *TextSearchCall) Do() (*SearchResponse, error) {
+	return t.do(callContext(t.ctx))
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("bad resp %d: %s", resp.StatusCode, body)
+func (t *TextSearchCall) do(ctx context.Context) (*SearchResponse, error) {
+	if err := t.validate(); err != nil {
+		return nil, err
 	}
 
 	data := &SearchResponse{}
-	if err := json.Unmarshal(body, data); err != nil {
+	if err := t.service.get(ctx, "textsearch", t.query(), data); err != nil {
 		return nil, err
 	}
 
-	if data.Status != "OK" {
-		return nil, &apiError{
-			Status:  data.Status,
-			Message: data.ErrorMessage,
-		}
-	}
-
 	return data, nil
 }
 
-func (t *TextSearchCall) query() string {
+func (t *TextSearchCall) query() url.Values {
 	query := make(url.Values)
-	query.Add("key", t.service.key)
 
 	if t.PageToken != "" {
 		query.Add("pagetoken", t.PageToken)
-		return query.Encode()
+		return query
 	}
 
-	if t.lat > 0 && t.lng > 0 {
-		query.Add("location", fmt.Sprintf("%f,%f", t.lat, t.lng))
+	if t.Location.IsSet() {
+		query.Add("location", t.Location.String())
 	}
 	if t.Type != "" {
 		query.Add("type", string(t.Type))
 	}
 
-	if t.Language != "" {
-		query.Add("language", t.Language)
+	if language := t.service.languageOr(t.Language); language != "" {
+		query.Add("language", language)
+	}
+	if region := t.service.regionOr(t.Region); region != "" {
+		query.Add("region", region)
 	}
 	if t.queryStr != "" {
 		query.Add("query", t.queryStr)
@@ -283,24 +291,24 @@ func (t *TextSearchCall) query() string {
 		query.Add("zagatselected", "")
 	}
 
-	return query.Encode()
+	return query
 }
 
 // RadarSearch returns results from up to 200 places, but with less detail than is typically returned from a Text Search or Nearby Search request.
 func (p *Service) RadarSearch(radius, lat, lng float64) *RadarSearchCall {
 	return &RadarSearchCall{
-		service: p,
-		radius:  radius,
-		lat:     lat,
-		lng:     lng,
+		service:  p,
+		radius:   radius,
+		location: NewLocation(lat, lng),
 	}
 }
 
 type RadarSearchCall struct {
 	service *Service
+	ctx     context.Context
 
 	// The latitude/longitude around which to retrieve place information
-	lat, lng float64
+	location Location
 	// The distance (in meters) within which to return place results. The maximum allowed radius is 50 000 meters.
 	radius float64
 
@@ -314,17 +322,16 @@ type RadarSearchCall struct {
 	Type FeatureType
 	// Restricts the search to locations that are Zagat selected businesses.
 	ZagatSelected bool
-	// Returns the next 20 results from a previously run search. Setting a pagetoken parameter will execute a search with the same parameters used previously — all parameters other than pagetoken will be ignored.
+	// Radar Search returns all of its results at once and never issues page tokens. Setting PageToken makes Do fail validation.
 	PageToken string
 }
 
-func (r *RadarSearchCall) query() string {
+func (r *RadarSearchCall) query() url.Values {
 	query := make(url.Values)
-	query.Add("key", r.service.key)
 	if r.Keyword != "" {
 		query.Add("keyword", r.Keyword)
 	}
-	query.Add("location", fmt.Sprintf("%f,%f", r.lat, r.lng))
+	query.Add("location", r.location.String())
 	query.Add("radius", fmt.Sprint(r.radius))
 	if r.MinPrice != nil {
 		query.Add("minprice", fmt.Sprint(*r.MinPrice))
@@ -345,32 +352,57 @@ func (r *RadarSearchCall) query() string {
 		query.Add("pagetoken", r.PageToken)
 	}
 
-	return query.Encode()
+	return query
 }
 
-func (r *RadarSearchCall) Do() (*SearchResponse, error) {
-	searchURL := baseURL + "/radarsearch/json?" + r.query()
+// Context sets the context used by Do. Cancelling it aborts the request.
+func (r *RadarSearchCall) Context(ctx context.Context) *RadarSearchCall {
+	r.ctx = ctx
+	return r
+}
 
-	resp, err := r.service.client.Get(searchURL)
-	if err != nil {
-		return nil, err
+// validate reports every invalid parameter of the call as ValidationErrors.
+func (r *RadarSearchCall) validate() error {
+	var v validator
+	v.check("location", r.location.validate())
+	if r.radius == 0 {
+		v.check("radius", errMissingRadius)
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		body, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("bad resp %d: %s", resp.StatusCode, body)
+	v.radius(r.radius)
+	if r.Keyword == "" && r.Type == "" {
+		v.check("keyword", errInvalidByRadar)
 	}
+	v.prices(r.MinPrice, r.MaxPrice)
+	v.text("keyword", r.Keyword)
+	v.featureType(r.Type)
+	if r.PageToken != "" {
+		v.check("pagetoken", errPageTokenUnsupported)
+	}
+	return v.err()
+}
 
-	data := &SearchResponse{}
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+// Request validates the call and returns the request Do would send, without credentials. Use Service.Authorize to add them before sending it.
+func (r *RadarSearchCall) Request(ctx context.Context) (*http.Request, error) {
+	if err := r.validate(); err != nil {
 		return nil, err
 	}
+	return r.service.request(callContext(ctx), "radarsearch", r.query())
+}
 
-	if data.Status != "OK" {
-		return nil, &apiError{
-			Status:  data.Status,
-			Message: data.ErrorMessage,
-		}
+// Do performs the RadarSearchCall request.
+func (r *RadarSearchCall) Do() (*SearchResponse, error) {
+	return r.do(callContext(r.ctx))
+}
+
+// do has no Pages counterpart, unlike the other searches, since Radar Search returns every result at once.
+func (r *RadarSearchCall) do(ctx context.Context) (*SearchResponse, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+
+	data := &SearchResponse{}
+	if err := r.service.get(ctx, "radarsearch", r.query(), data); err != nil {
+		return nil, err
 	}
 
 	return data, nil
@@ -389,6 +421,18 @@ type SearchResponse struct {
 	NextPageToken string `json:"next_page_token"`
 }
 
+func (r *SearchResponse) apiStatus() (string, string) {
+	return r.Status, r.ErrorMessage
+}
+
+func (r *SearchResponse) resultCount() int {
+	return len(r.Results)
+}
+
+func (r *SearchResponse) nextPageToken() string {
+	return r.NextPageToken
+}
+
 // RankBy specifies the order in which results are listed.
 type RankBy string
 
@@ -1,6 +1,10 @@
 package places
 
-import "testing"
+import (
+	"errors"
+	"strings"
+	"testing"
+)
 
 var dummyService = &Service{}
 
@@ -68,9 +72,146 @@ func TestNearbyCallValidate(t *testing.T) {
 			},
 			Want: nil,
 		},
+		{
+			Name: "unsupported language",
+			Call: NearbyCall{
+				Radius:   5,
+				Language: "tlh",
+			},
+			Want: errUnsupportedLanguage,
+		},
+		{
+			Name: "unsupported service language",
+			Call: NearbyCall{
+				service: &Service{language: "xx"},
+				Radius:  5,
+			},
+			Want: errUnsupportedLanguage,
+		},
+		{
+			Name: "radius too great",
+			Call: NearbyCall{
+				Radius: maximumRadius + 1,
+			},
+			Want: errRadiusIsTooGreat,
+		},
+		{
+			Name: "negative radius",
+			Call: NearbyCall{
+				Radius: -5,
+			},
+			Want: errNegativeRadius,
+		},
+		{
+			Name: "distance call with radius",
+			Call: NearbyCall{
+				RankBy: RankByDistance,
+				Type:   Bar,
+				Radius: 500,
+			},
+			Want: errRadiusWithDistance,
+		},
+		{
+			Name: "unknown rankby",
+			Call: NearbyCall{
+				RankBy: "rating",
+				Radius: 5,
+			},
+			Want: errInvalidRankBy,
+		},
+		{
+			Name: "min price above max price",
+			Call: NearbyCall{
+				Radius:   5,
+				MinPrice: priceLevel(Expensive),
+				MaxPrice: priceLevel(Inexpensive),
+			},
+			Want: errInvalidPriceRange,
+		},
+		{
+			Name: "equal min and max price",
+			Call: NearbyCall{
+				Radius:   5,
+				MinPrice: priceLevel(Moderate),
+				MaxPrice: priceLevel(Moderate),
+			},
+			Want: nil,
+		},
+		{
+			Name: "price level out of range",
+			Call: NearbyCall{
+				Radius:   5,
+				MaxPrice: priceLevel(5),
+			},
+			Want: errInvalidPriceLevel,
+		},
+		{
+			Name: "keyword too long",
+			Call: NearbyCall{
+				Radius:  5,
+				Keyword: strings.Repeat("ü", maxTextLength+1),
+			},
+			Want: errTextTooLong,
+		},
+		{
+			Name: "unknown type",
+			Call: NearbyCall{
+				Radius: 5,
+				Type:   "bars",
+			},
+			Want: errUnknownType,
+		},
+		{
+			Name: "response-only type",
+			Call: NearbyCall{
+				Radius: 5,
+				Type:   PointOfInterest,
+			},
+			Want: errResponseOnlyType,
+		},
+		{
+			Name: "page token only",
+			Call: NearbyCall{
+				PageToken: "token",
+			},
+			Want: nil,
+		},
+		{
+			Name: "page token by distance",
+			Call: NearbyCall{
+				PageToken: "token",
+				RankBy:    RankByDistance,
+			},
+			Want: nil,
+		},
+		{
+			Name: "page token with invalid rank",
+			Call: NearbyCall{
+				PageToken: "token",
+				RankBy:    "rating",
+			},
+			Want: errInvalidRankBy,
+		},
+		{
+			Name: "page token with invalid radius",
+			Call: NearbyCall{
+				PageToken: "token",
+				Radius:    60000,
+			},
+			Want: errRadiusIsTooGreat,
+		},
+		{
+			Name: "language overrides service language",
+			Call: NearbyCall{
+				service:  &Service{language: "xx"},
+				Radius:   5,
+				Language: "zh-TW",
+			},
+			Want: nil,
+		},
 	} {
 		got := test.Call.validate()
-		if got != test.Want {
+		if !errors.Is(got, test.Want) {
 			t.Errorf("NearbyCall{%v}.query() = %#v, want %#v",
 				test.Name, got, test.Want)
 		}
@@ -99,8 +240,7 @@ func TestTextSearchValidate(t *testing.T) {
 			Name: "Missing radius",
 			Call: TextSearchCall{
 				queryStr: "foo",
-				lat:      0.0,
-				lng:      0.1,
+				Location: NewLocation(0.0, 0.1),
 			},
 			Want: errMissingRadius,
 		},
@@ -108,8 +248,7 @@ func TestTextSearchValidate(t *testing.T) {
 			Name: "Incorrect radius",
 			Call: TextSearchCall{
 				queryStr: "foo",
-				lat:      27.988056,
-				lng:      86.925278,
+				Location: NewLocation(27.988056, 86.925278),
 				Radius:   (maximumRadius + 1),
 			},
 			Want: errRadiusIsTooGreat,
@@ -118,17 +257,213 @@ func TestTextSearchValidate(t *testing.T) {
 			Name: "With correct location and radius",
 			Call: TextSearchCall{
 				queryStr: "foo",
-				lat:      27.988056,
-				lng:      86.925278,
+				Location: NewLocation(27.988056, 86.925278),
 				Radius:   maximumRadius,
 			},
 			Want: nil,
 		},
+		{
+			Name: "Location at 0,0 without radius",
+			Call: TextSearchCall{
+				queryStr: "foo",
+				Location: NewLocation(0, 0),
+			},
+			Want: errMissingRadius,
+		},
+		{
+			Name: "Location in the southern and western hemispheres",
+			Call: TextSearchCall{
+				queryStr: "foo",
+				Location: NewLocation(-34.6037, -58.3816),
+				Radius:   1000,
+			},
+			Want: nil,
+		},
+		{
+			Name: "Location out of range",
+			Call: TextSearchCall{
+				queryStr: "foo",
+				Location: NewLocation(-95, 10),
+				Radius:   1000,
+			},
+			Want: errLatitudeOutOfRange,
+		},
+		{
+			Name: "Negative radius",
+			Call: TextSearchCall{
+				queryStr: "foo",
+				Radius:   -1,
+			},
+			Want: errNegativeRadius,
+		},
+		{
+			Name: "Min price above max price",
+			Call: TextSearchCall{
+				queryStr: "foo",
+				MinPrice: priceLevel(VeryExpensive),
+				MaxPrice: priceLevel(Free),
+			},
+			Want: errInvalidPriceRange,
+		},
+		{
+			Name: "Query too long",
+			Call: TextSearchCall{
+				queryStr: strings.Repeat("a", maxTextLength+1),
+			},
+			Want: errTextTooLong,
+		},
+		{
+			Name: "Unknown type",
+			Call: TextSearchCall{
+				queryStr: "foo",
+				Type:     "pizza",
+			},
+			Want: errUnknownType,
+		},
+		{
+			Name: "With page token only",
+			Call: TextSearchCall{
+				PageToken: "token",
+			},
+			Want: nil,
+		},
+		{
+			Name: "With page token and unknown type",
+			Call: TextSearchCall{
+				PageToken: "token",
+				Type:      "pizza",
+			},
+			Want: errUnknownType,
+		},
+		{
+			Name: "With unsupported language",
+			Call: TextSearchCall{
+				queryStr: "foo",
+				Language: "english",
+			},
+			Want: errUnsupportedLanguage,
+		},
+		{
+			Name: "With regional language",
+			Call: TextSearchCall{
+				queryStr: "foo",
+				Language: "pt-br",
+			},
+			Want: nil,
+		},
 	} {
 		got := test.Call.validate()
-		if got != test.Want {
+		if !errors.Is(got, test.Want) {
 			t.Errorf("TextSearchCall{%v}.query() = %#v, want %#v",
 				test.Name, got, test.Want)
 		}
 	}
 }
+
+func TestRadarSearchValidate(t *testing.T) {
+	for _, test := range []struct {
+		Name string
+		Call *RadarSearchCall
+		Want error
+	}{
+		{
+			Name: "valid keyword call",
+			Call: &RadarSearchCall{location: NewLocation(51.5, -0.12), radius: 500, Keyword: "pub"},
+			Want: nil,
+		},
+		{
+			Name: "valid type call",
+			Call: &RadarSearchCall{location: NewLocation(51.5, -0.12), radius: 500, Type: Bar},
+			Want: nil,
+		},
+		{
+			Name: "missing criteria",
+			Call: &RadarSearchCall{location: NewLocation(51.5, -0.12), radius: 500},
+			Want: errInvalidByRadar,
+		},
+		{
+			Name: "missing radius",
+			Call: &RadarSearchCall{location: NewLocation(51.5, -0.12), Keyword: "pub"},
+			Want: errMissingRadius,
+		},
+		{
+			Name: "radius too great",
+			Call: &RadarSearchCall{location: NewLocation(51.5, -0.12), radius: maximumRadius + 1, Keyword: "pub"},
+			Want: errRadiusIsTooGreat,
+		},
+		{
+			Name: "longitude out of range",
+			Call: &RadarSearchCall{location: NewLocation(51.5, -200), radius: 500, Keyword: "pub"},
+			Want: errLongitudeOutOfRange,
+		},
+		{
+			Name: "page token",
+			Call: &RadarSearchCall{location: NewLocation(51.5, -0.12), radius: 500, Keyword: "pub", PageToken: "token"},
+			Want: errPageTokenUnsupported,
+		},
+	} {
+		got := test.Call.validate()
+		if !errors.Is(got, test.Want) {
+			t.Errorf("RadarSearchCall{%v}.validate() = %v, want %v",
+				test.Name, got, test.Want)
+		}
+	}
+}
+
+func priceLevel(p PriceLevel) *PriceLevel {
+	return &p
+}
+
+func TestSearchQueryLocation(t *testing.T) {
+	for _, test := range []struct {
+		Name  string
+		Query func() string
+		Want  string
+	}{
+		{
+			Name: "nearby in the western hemisphere",
+			Query: func() string {
+				return dummyService.Nearby(37.7833, -122.4167).query().Get("location")
+			},
+			Want: "37.783300,-122.416700",
+		},
+		{
+			Name: "text search in the southern hemisphere",
+			Query: func() string {
+				call := dummyService.TextSearch("cafe")
+				call.Location = NewLocation(-33.8670522, 151.1957362)
+				call.Radius = 500
+				return call.query().Get("location")
+			},
+			Want: "-33.867052,151.195736",
+		},
+		{
+			Name: "text search in the southern and western hemispheres",
+			Query: func() string {
+				call := dummyService.TextSearch("cafe")
+				call.Location = NewLocation(-34.6037, -58.3816)
+				call.Radius = 500
+				return call.query().Get("location")
+			},
+			Want: "-34.603700,-58.381600",
+		},
+		{
+			Name: "text search without location",
+			Query: func() string {
+				return dummyService.TextSearch("cafe").query().Get("location")
+			},
+			Want: "",
+		},
+		{
+			Name: "radar search in the western hemisphere",
+			Query: func() string {
+				return dummyService.RadarSearch(500, 40.7128, -74.0060).query().Get("location")
+			},
+			Want: "40.712800,-74.006000",
+		},
+	} {
+		if got := test.Query(); got != test.Want {
+			t.Errorf("%s: location = %q, want %q", test.Name, got, test.Want)
+		}
+	}
+}
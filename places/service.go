@@ -1,26 +1,328 @@
 // Package places has been deprecated. Please use the official client: https://github.com/googlemaps/google-maps-services-go
 package places
 
-import "net/http"
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+)
 
 const baseURL = "https://maps.googleapis.com/maps/api/place"
 
+// Service is a client for the Google Places API. It is configured when it is created and is safe for concurrent use.
 type Service struct {
-	client *http.Client
-	key    string
-	url    string
+	client    *http.Client
+	key       string
+	url       string
+	language  string
+	region    string
+	userAgent string
+	timeout   time.Duration
+
+	keys    *KeyPool
+	creds   *ClientCredentials
+	limiter Limiter
+	cache   Cache
+	retry   *RetryPolicy
+	breaker *CircuitBreaker
+
+	collector Collector
+	logger    *slog.Logger
+	logLevels *logLevels
+	tracer    Tracer
+
+	dryRun *DryRun
 }
 
-// NewService creates a new places service with the given http client and Google Plus Places API key
-func NewService(client *http.Client, key string) *Service {
-	return &Service{
+// NewService creates a new places service with the given http client and Google Plus Places API key, configured by any options.
+func NewService(client *http.Client, key string, opts ...Option) *Service {
+	s := &Service{
 		client: client,
 		key:    key,
 		url:    baseURL,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // SetURL allows overwriting the base url
+//
+// Deprecated: Use WithBaseURL. SetURL must not be called while the service is in use.
 func (s *Service) SetURL(url string) {
 	s.url = url
 }
+
+// callContext returns the context set on a call, or the background context if there is none.
+func callContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx
+}
+
+// apiResponse is implemented by the response types of every Places endpoint.
+type apiResponse interface {
+	apiStatus() (status, message string)
+	resultCount() int
+	nextPageToken() string
+}
+
+// get performs a request against the named endpoint (e.g. "nearbysearch") and decodes the response into data. A non-OK status in the response body is returned as an *apiError.
+func (s *Service) get(ctx context.Context, endpoint string, query url.Values, data apiResponse) error {
+	if s.dryRun != nil {
+		return s.dryRun.record(ctx, s, endpoint, query, data)
+	}
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	ctx, span := s.startSpan(ctx, "places."+endpoint, Attr("places.endpoint", endpoint))
+
+	if s.cache != nil && s.cached(ctx, endpoint, query, data) {
+		span.SetAttributes(
+			Attr("places.status", "OK"),
+			Attr("places.result_count", data.resultCount()),
+		)
+		span.End(nil)
+		return nil
+	}
+
+	start := time.Now()
+	err := s.guard(ctx, endpoint, query, data)
+	if s.collector != nil {
+		s.collector.ObserveRequest(endpoint, statusOf(err), time.Since(start))
+	}
+
+	span.SetAttributes(
+		Attr("places.status", statusOf(err)),
+		Attr("places.result_count", data.resultCount()),
+	)
+	span.End(err)
+	return err
+}
+
+// cached decodes a cached response into data, reporting whether there was one.
+func (s *Service) cached(ctx context.Context, endpoint string, query url.Values, data apiResponse) bool {
+	_, span := s.startSpan(ctx, "places.cache", Attr("places.endpoint", endpoint))
+
+	body, hit := s.cache.Get(cacheKey(endpoint, query))
+	if hit && json.Unmarshal(body, data) != nil {
+		hit = false
+	}
+	if s.collector != nil {
+		s.collector.ObserveCache(endpoint, hit)
+	}
+
+	span.SetAttributes(Attr("places.cache_hit", hit))
+	span.End(nil)
+	return hit
+}
+
+// cacheKey identifies a request in the cache. The query never contains credentials.
+func cacheKey(endpoint string, query url.Values) string {
+	return endpoint + "?" + query.Encode()
+}
+
+// guard sends the request through the circuit breaker, if there is one.
+func (s *Service) guard(ctx context.Context, endpoint string, query url.Values, data apiResponse) error {
+	if s.breaker == nil {
+		return s.retrying(ctx, endpoint, query, data)
+	}
+
+	trial, err := s.breaker.allow(endpoint)
+	if err != nil {
+		return err
+	}
+	err = s.retrying(ctx, endpoint, query, data)
+	s.breaker.done(endpoint, trial, err)
+	return err
+}
+
+// retrying sends the request again after transient failures, as allowed by the retry policy.
+func (s *Service) retrying(ctx context.Context, endpoint string, query url.Values, data apiResponse) error {
+	attempts := 0
+	for retry := 1; ; retry++ {
+		err := s.send(ctx, endpoint, query, data, &attempts)
+		if s.retry == nil || retry >= s.retry.MaxAttempts || !s.retry.retryable(err) {
+			return err
+		}
+		if s.collector != nil {
+			s.collector.ObserveRetry(endpoint, statusOf(err))
+		}
+		if sleep(ctx, s.retry.backoff(retry)) != nil {
+			return err
+		}
+	}
+}
+
+// send fetches the endpoint, failing over to the next key in the KeyPool if the current one is over its quota or denied. attempts counts the HTTP requests made for the call.
+func (s *Service) send(ctx context.Context, endpoint string, query url.Values, data apiResponse, attempts *int) error {
+	if s.keys == nil || s.creds != nil {
+		*attempts++
+		return s.attempt(ctx, endpoint, query, s.key, *attempts, data)
+	}
+
+	// Each attempt decodes into a fresh response so that fields left over from a rejected attempt, such as its error message, don't leak into the one that is returned.
+	var err error
+	for i := 0; i < s.keys.Len(); i++ {
+		key, pickErr := s.keys.pick()
+		if pickErr != nil {
+			if err != nil {
+				return err
+			}
+			return pickErr
+		}
+
+		*attempts++
+		fresh := reflect.New(reflect.TypeOf(data).Elem()).Interface().(apiResponse)
+		err = s.attempt(ctx, endpoint, query, key, *attempts, fresh)
+		s.keys.report(key, err)
+		if !IsOverQueryLimit(err) && !IsRequestDenied(err) || i+1 == s.keys.Len() {
+			reflect.ValueOf(data).Elem().Set(reflect.ValueOf(fresh).Elem())
+			return err
+		}
+		if s.collector != nil && i+1 < s.keys.Len() {
+			s.collector.ObserveRetry(endpoint, statusOf(err))
+		}
+	}
+	return err
+}
+
+// attempt fetches the endpoint once, once the limiter allows it, tracing and logging the outcome.
+func (s *Service) attempt(ctx context.Context, endpoint string, query url.Values, key string, n int, data apiResponse) error {
+	if s.limiter != nil {
+		if err := s.limiter.Wait(ctx); err != nil {
+			return &limiterError{err}
+		}
+	}
+
+	ctx, span := s.startSpan(ctx, "places.attempt",
+		Attr("places.endpoint", endpoint),
+		Attr("places.attempt", n),
+	)
+	header := http.Header{}
+	if carrier, ok := span.(SpanContextCarrier); ok {
+		carrier.SpanContext().Inject(header)
+	}
+
+	start := time.Now()
+	err := s.fetch(ctx, endpoint, query, key, header, data)
+	if s.logger != nil {
+		s.logAttempt(ctx, endpoint, query, n, data, err, time.Since(start))
+	}
+
+	span.SetAttributes(Attr("places.status", statusOf(err)))
+	span.End(err)
+	return err
+}
+
+// fetch performs a single HTTP request using the given key, or the client credentials if they are set. Successful responses are stored in the cache.
+func (s *Service) fetch(ctx context.Context, endpoint string, query url.Values, key string, header http.Header, data apiResponse) error {
+	req, err := s.request(ctx, endpoint, query)
+	if err != nil {
+		return err
+	}
+	s.authorize(req, key)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &httpError{
+			StatusCode: resp.StatusCode,
+			Body:       body,
+		}
+	}
+
+	if err := json.Unmarshal(body, data); err != nil {
+		return err
+	}
+
+	if status, message := data.apiStatus(); status != "OK" {
+		return &apiError{
+			Status:  status,
+			Message: message,
+		}
+	}
+
+	if s.cache != nil {
+		s.cache.Set(cacheKey(endpoint, query), body)
+	}
+	return nil
+}
+
+// request builds the request for endpoint without any credentials.
+func (s *Service) request(ctx context.Context, endpoint string, query url.Values) (*http.Request, error) {
+	u, err := url.Parse(s.url + "/" + endpoint + "/json")
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.userAgent != "" {
+		req.Header.Set("User-Agent", s.userAgent)
+	}
+	return req, nil
+}
+
+// authorize adds the given key to req, or signs it if the service has client credentials.
+func (s *Service) authorize(req *http.Request, key string) {
+	params := req.URL.Query()
+	if s.creds != nil {
+		req.URL.RawQuery = s.creds.authorize(req.URL.EscapedPath(), params)
+		return
+	}
+	params.Set("key", key)
+	req.URL.RawQuery = params.Encode()
+}
+
+// Authorize adds the service's credentials to a request built by one of the calls' Request methods: a signature if the service has client credentials, otherwise the next key from its KeyPool, which doesn't count the request in its Stats, or its API key. It must be called at most once for each request.
+func (s *Service) Authorize(req *http.Request) error {
+	key := s.key
+	if s.keys != nil && s.creds == nil {
+		var err error
+		// The service never sees the response to an authorized request, so the key's statistics don't count it.
+		if key, err = s.keys.peek(); err != nil {
+			return err
+		}
+	}
+	s.authorize(req, key)
+	return nil
+}
+
+// languageOr returns language, or the service's default language if it is empty.
+func (s *Service) languageOr(language string) string {
+	if language == "" && s != nil {
+		return s.language
+	}
+	return language
+}
+
+// regionOr returns region, or the service's default region if it is empty.
+func (s *Service) regionOr(region string) string {
+	if region == "" && s != nil {
+		return s.region
+	}
+	return region
+}
@@ -1,8 +1,13 @@
 package places
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestNewService(t *testing.T) {
@@ -39,3 +44,130 @@ func TestSetURL(t *testing.T) {
 		t.Errorf("Service{}.SetURL() %#v = %#v", service.url, url)
 	}
 }
+
+func TestNewServiceOptions(t *testing.T) {
+	pool := NewKeyPool(RoundRobin, "a")
+	service := NewService(http.DefaultClient, "key",
+		WithBaseURL("https://localhost/maps/api/place"),
+		WithLanguage("ja"),
+		WithRegion("jp"),
+		WithUserAgent("places-test/1.0"),
+		WithTimeout(time.Second),
+		WithKeyPool(pool),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3}),
+	)
+
+	want := Service{
+		client:    http.DefaultClient,
+		key:       "key",
+		url:       "https://localhost/maps/api/place",
+		language:  "ja",
+		region:    "jp",
+		userAgent: "places-test/1.0",
+		timeout:   time.Second,
+		keys:      pool,
+		retry:     service.retry,
+	}
+	if *service != want {
+		t.Errorf("NewService() = %#v, want %#v", service, want)
+	}
+	if service.retry.MaxAttempts != 3 {
+		t.Errorf("NewService().retry = %#v, want MaxAttempts 3", service.retry)
+	}
+}
+
+func TestServiceDefaults(t *testing.T) {
+	var uri, userAgent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uri = r.URL.RequestURI()
+		userAgent = r.Header.Get("User-Agent")
+		fmt.Fprint(w, `{"status": "OK"}`)
+	}))
+	defer ts.Close()
+
+	service := NewService(http.DefaultClient, "key",
+		WithBaseURL(ts.URL),
+		WithLanguage("ja"),
+		WithRegion("jp"),
+		WithUserAgent("places-test/1.0"),
+	)
+
+	for _, test := range []struct {
+		Name string
+		Do   func() error
+		Want string
+	}{
+		{
+			Name: "details inherits language and region",
+			Do: func() error {
+				_, err := service.Details("place").Do()
+				return err
+			},
+			Want: "/details/json?key=key&language=ja&placeid=place&region=jp",
+		},
+		{
+			Name: "details overrides language",
+			Do: func() error {
+				call := service.Details("place")
+				call.Language = "en"
+				_, err := call.Do()
+				return err
+			},
+			Want: "/details/json?key=key&language=en&placeid=place&region=jp",
+		},
+		{
+			Name: "details overrides region",
+			Do: func() error {
+				call := service.Details("place")
+				call.Region = "us"
+				_, err := call.Do()
+				return err
+			},
+			Want: "/details/json?key=key&language=ja&placeid=place&region=us",
+		},
+		{
+			Name: "text search inherits language and region",
+			Do: func() error {
+				_, err := service.TextSearch("ramen").Do()
+				return err
+			},
+			Want: "/textsearch/json?key=key&language=ja&query=ramen&region=jp",
+		},
+		{
+			Name: "text search overrides region",
+			Do: func() error {
+				call := service.TextSearch("ramen")
+				call.Region = "kr"
+				_, err := call.Do()
+				return err
+			},
+			Want: "/textsearch/json?key=key&language=ja&query=ramen&region=kr",
+		},
+	} {
+		if err := test.Do(); err != nil {
+			t.Fatalf("%s: %v", test.Name, err)
+		}
+		if uri != test.Want {
+			t.Errorf("%s: request = %s, want %s", test.Name, uri, test.Want)
+		}
+		if userAgent != "places-test/1.0" {
+			t.Errorf("%s: User-Agent = %q, want places-test/1.0", test.Name, userAgent)
+		}
+	}
+}
+
+func TestServiceTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer ts.Close()
+
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL), WithTimeout(10*time.Millisecond))
+	_, err := service.Details("place").Do()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("DetailsCall{}.Do() = %v, want deadline exceeded", err)
+	}
+}
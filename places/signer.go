@@ -0,0 +1,58 @@
+package places
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+var errMissingClientID = errors.New("a client ID is required for signed requests")
+
+// ClientCredentials authenticate requests with a client ID and URL signature instead of an API key, as used by Google Maps APIs Premium Plan customers.
+type ClientCredentials struct {
+	clientID string
+	channel  string
+	secret   []byte
+}
+
+// NewClientCredentials creates credentials from a client ID and signing secret. The secret is the base64-encoded value shown in the Google Cloud console. The channel, which may be empty, is sent with every request so usage can be broken down in reports.
+func NewClientCredentials(clientID, secret, channel string) (*ClientCredentials, error) {
+	if clientID == "" {
+		return nil, errMissingClientID
+	}
+	decoded, err := decodeSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientCredentials{
+		clientID: clientID,
+		channel:  channel,
+		secret:   decoded,
+	}, nil
+}
+
+// decodeSecret accepts the secret in either URL-safe or standard base64, with or without padding.
+func decodeSecret(secret string) ([]byte, error) {
+	secret = strings.NewReplacer("+", "-", "/", "_").Replace(secret)
+	return base64.URLEncoding.DecodeString(secret + strings.Repeat("=", (4-len(secret)%4)%4))
+}
+
+// authorize adds the client and channel parameters to query and returns the signed query string for a request to path.
+func (c *ClientCredentials) authorize(path string, query url.Values) string {
+	query.Set("client", c.clientID)
+	if c.channel != "" {
+		query.Set("channel", c.channel)
+	}
+	encoded := query.Encode()
+	return encoded + "&signature=" + c.sign(path+"?"+encoded)
+}
+
+// sign returns the URL-safe base64 HMAC-SHA1 signature of the path and query portion of a URL.
+func (c *ClientCredentials) sign(pathAndQuery string) string {
+	mac := hmac.New(sha1.New, c.secret)
+	mac.Write([]byte(pathAndQuery))
+	return base64.URLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,82 @@
+package places
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestClientCredentialsSign(t *testing.T) {
+	for _, test := range []struct {
+		Name   string
+		Secret string
+		URL    string
+		Want   string
+	}{
+		{
+			// https://developers.google.com/maps/documentation/maps-static/digital-signature
+			Name:   "documented example",
+			Secret: "vNIXE0xscrmjlyV-12Nj_BvUPaw=",
+			URL:    "/maps/api/geocode/json?address=New+York&client=clientID",
+			Want:   "chaRF2hTJKOScPr-RQCEhZbSzIE=",
+		},
+		{
+			Name:   "unpadded standard base64 secret",
+			Secret: "vNIXE0xscrmjlyV+12Nj/BvUPaw",
+			URL:    "/maps/api/geocode/json?address=New+York&client=clientID",
+			Want:   "chaRF2hTJKOScPr-RQCEhZbSzIE=",
+		},
+	} {
+		creds, err := NewClientCredentials("clientID", test.Secret, "")
+		if err != nil {
+			t.Fatalf("%s: NewClientCredentials() = %v", test.Name, err)
+		}
+
+		got := creds.sign(test.URL)
+		if got != test.Want {
+			t.Errorf("ClientCredentials{}.sign() %v = %q, want %q", test.Name, got, test.Want)
+		}
+	}
+}
+
+func TestNewClientCredentialsInvalid(t *testing.T) {
+	if _, err := NewClientCredentials("", "vNIXE0xscrmjlyV-12Nj_BvUPaw=", ""); err != errMissingClientID {
+		t.Errorf("NewClientCredentials() with empty client = %v, want %v", err, errMissingClientID)
+	}
+	if _, err := NewClientCredentials("clientID", "not*base64", ""); err == nil {
+		t.Error("NewClientCredentials() with invalid secret = nil, want error")
+	}
+}
+
+func TestServiceSignedRequest(t *testing.T) {
+	var got *url.URL
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL
+		fmt.Fprint(w, `{"status": "OK"}`)
+	}))
+	defer ts.Close()
+
+	creds, err := NewClientCredentials("gme-example", "vNIXE0xscrmjlyV-12Nj_BvUPaw=", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := NewService(http.DefaultClient, "unused",
+		WithBaseURL(ts.URL+"/maps/api/place"),
+		WithClientCredentials(creds),
+	)
+
+	if _, err := service.Details("ChIJLU7jZClu5kcR4PcOOO6p3I0").Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "/maps/api/place/details/json?channel=web&client=gme-example&placeid=ChIJLU7jZClu5kcR4PcOOO6p3I0"
+	wantSig := creds.sign(want)
+	if got.Path+"?"+got.RawQuery != want+"&signature="+wantSig {
+		t.Errorf("signed request = %s?%s, want %s&signature=%s", got.Path, got.RawQuery, want, wantSig)
+	}
+	if got.Query().Get("key") != "" {
+		t.Errorf("signed request carries key %q", got.Query().Get("key"))
+	}
+}
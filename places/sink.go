@@ -0,0 +1,67 @@
+package places
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// A Sink receives the changes a Monitor finds when it refreshes a place.
+type Sink interface {
+	// Send delivers the changes to one place. It is called from a single goroutine at a time.
+	Send(ctx context.Context, changes []Change) error
+}
+
+// SinkFunc is a Sink that calls a function, e.g. to send an alert only for some kinds of change.
+type SinkFunc func(ctx context.Context, changes []Change) error
+
+// Send implements Sink.
+func (f SinkFunc) Send(ctx context.Context, changes []Change) error {
+	return f(ctx, changes)
+}
+
+// ChannelSink returns a Sink that sends each change to ch, waiting for it to be received unless ctx ends first.
+func ChannelSink(ch chan<- Change) Sink {
+	return SinkFunc(func(ctx context.Context, changes []Change) error {
+		for _, change := range changes {
+			select {
+			case ch <- change:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+}
+
+// WebhookSink returns a Sink that POSTs the changes to url with client, as a JSON object whose "changes" member is the list of changes, each encoded as in ChangeReport. Any response status other than 2xx is an error.
+func WebhookSink(client *http.Client, url string) Sink {
+	return SinkFunc(func(ctx context.Context, changes []Change) error {
+		body, err := json.Marshal(struct {
+			Changes []Change `json:"changes"`
+		}{changes})
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		io.Copy(ioutil.Discard, resp.Body)
+		if resp.StatusCode/100 != 2 {
+			return fmt.Errorf("webhook returned %s", resp.Status)
+		}
+		return nil
+	})
+}
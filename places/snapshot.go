@@ -0,0 +1,303 @@
+package places
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// ChangeKind is the kind of a change between two snapshots of places.
+type ChangeKind string
+
+// The kinds of change, in the order they are listed in a report.
+const (
+	// PlaceAdded is a place that is only in the newer snapshot.
+	PlaceAdded ChangeKind = "added"
+	// PlaceRemoved is a place that is only in the older snapshot.
+	PlaceRemoved ChangeKind = "removed"
+	// PlaceClosed is a place that has closed, temporarily or permanently.
+	PlaceClosed ChangeKind = "closed"
+	// PlaceReopened is a place that was closed and is now operational.
+	PlaceReopened ChangeKind = "reopened"
+	// PlaceRenamed is a place whose name changed.
+	PlaceRenamed ChangeKind = "renamed"
+	// HoursChanged is a place whose regular opening hours changed.
+	HoursChanged ChangeKind = "hours_changed"
+	// RatingChanged is a place whose rating changed by at least the comparer's threshold.
+	RatingChanged ChangeKind = "rating_changed"
+	// PlaceUpdated is a place with changes to any other fields, such as its address, phone number or website.
+	PlaceUpdated ChangeKind = "updated"
+)
+
+var changeKinds = []ChangeKind{PlaceAdded, PlaceRemoved, PlaceClosed, PlaceReopened, PlaceRenamed, HoursChanged, RatingChanged, PlaceUpdated}
+
+// ignoredFields are the fields that change too often to be worth reporting, or that are covered by a more specific kind of change.
+var ignoredFields = map[string]bool{
+	"place_id":              true,
+	"alt_ids":               true,
+	"scope":                 true,
+	"name":                  true,
+	"business_status":       true,
+	"permanently_closed":    true,
+	"opening_hours":         true,
+	"current_opening_hours": true,
+	"rating":                true,
+	"user_ratings_total":    true,
+	"reviews":               true,
+	"photos":                true,
+}
+
+// Change is a change to one place between two snapshots.
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	// PlaceID is the ID of the place in the newer snapshot, or in the older one if it was removed.
+	PlaceID string `json:"place_id"`
+	// PreviousID is the ID the place had in the older snapshot, if it was matched by one of its alternative IDs.
+	PreviousID string `json:"previous_id,omitempty"`
+	// Name is the name of the place in the newer snapshot, or in the older one if it was removed.
+	Name string `json:"name"`
+	// Old and New are the values that changed: the name, rating or business status, or the opening periods. They are not set for added, removed and updated places.
+	Old interface{} `json:"old,omitempty"`
+	New interface{} `json:"new,omitempty"`
+	// Fields lists the JSON names of the fields that changed in an updated place.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// ChangeReport is the result of comparing two snapshots of places.
+type ChangeReport struct {
+	// Before and After are the number of places in the older and newer snapshot.
+	Before int `json:"before"`
+	After  int `json:"after"`
+	// Summary is the number of changes of each kind.
+	Summary map[ChangeKind]int `json:"summary"`
+	// Changes lists the changes to each place in the order of the newer snapshot, followed by the removed places in the order of the older one.
+	Changes []Change `json:"changes"`
+}
+
+// Comparer finds the changes between two snapshots of the same set of places, such as the results of a weekly job. The zero value reports every change.
+type Comparer struct {
+	// RatingThreshold is the smallest change in rating that is reported. Zero reports any change.
+	RatingThreshold float64
+}
+
+// Compare returns the changes from the places in before to those in after. Places are matched by PlaceID, or by their alternative IDs when a place's ID has changed, e.g. once an application-scoped place has been moderated. If an ID appears more than once in a snapshot only the first place with it is used.
+func (c Comparer) Compare(before, after []PlaceDetails) *ChangeReport {
+	report := &ChangeReport{
+		Before:  len(before),
+		After:   len(after),
+		Summary: map[ChangeKind]int{},
+	}
+
+	index := map[string]int{}
+	for i := range before {
+		for _, id := range placeIDs(&before[i]) {
+			if _, ok := index[id]; !ok {
+				index[id] = i
+			}
+		}
+	}
+
+	matched := make([]bool, len(before))
+	seen := map[string]bool{}
+	for i := range after {
+		p := &after[i]
+		if seen[p.PlaceID] {
+			continue
+		}
+		seen[p.PlaceID] = true
+
+		j, ok := -1, false
+		for _, id := range placeIDs(p) {
+			if j, ok = index[id]; ok && !matched[j] {
+				break
+			}
+			ok = false
+		}
+		if !ok {
+			report.add(Change{Kind: PlaceAdded, PlaceID: p.PlaceID, Name: p.Name})
+			continue
+		}
+		matched[j] = true
+		for _, change := range c.changes(&before[j], p) {
+			report.add(change)
+		}
+	}
+
+	handled := map[string]bool{}
+	for i := range before {
+		if matched[i] {
+			handled[before[i].PlaceID] = true
+		}
+	}
+	for i := range before {
+		if !handled[before[i].PlaceID] {
+			handled[before[i].PlaceID] = true
+			report.add(Change{Kind: PlaceRemoved, PlaceID: before[i].PlaceID, Name: before[i].Name})
+		}
+	}
+	return report
+}
+
+// placeIDs returns the ID of the place followed by its alternative IDs.
+func placeIDs(p *PlaceDetails) []string {
+	ids := []string{p.PlaceID}
+	for _, alt := range p.AltIDs {
+		ids = append(ids, alt.PlaceID)
+	}
+	return ids
+}
+
+// changes returns the changes from old to p, which are the same place.
+func (c Comparer) changes(old, p *PlaceDetails) []Change {
+	base := Change{PlaceID: p.PlaceID, Name: p.Name}
+	if old.PlaceID != p.PlaceID {
+		base.PreviousID = old.PlaceID
+	}
+	with := func(kind ChangeKind, before, after interface{}) Change {
+		change := base
+		change.Kind, change.Old, change.New = kind, before, after
+		return change
+	}
+
+	var changes []Change
+	// A place that was closed temporarily and is now closed permanently has closed again, so the statuses are compared rather than just whether the place is closed.
+	switch before, after := old.status(), p.status(); {
+	case before != after && p.closed():
+		changes = append(changes, with(PlaceClosed, before, after))
+	case old.closed() && !p.closed():
+		changes = append(changes, with(PlaceReopened, before, after))
+	}
+	if old.Name != p.Name {
+		changes = append(changes, with(PlaceRenamed, old.Name, p.Name))
+	}
+	if old.Has("opening_hours") && p.Has("opening_hours") && !reflect.DeepEqual(old.OpeningHours.Periods, p.OpeningHours.Periods) {
+		changes = append(changes, with(HoursChanged, old.OpeningHours.Periods, p.OpeningHours.Periods))
+	}
+	if old.Has("rating") && p.Has("rating") && old.Rating != p.Rating && abs(p.Rating-old.Rating) >= c.RatingThreshold {
+		changes = append(changes, with(RatingChanged, old.Rating, p.Rating))
+	}
+
+	var fields []string
+	for _, change := range old.Diff(p) {
+		if change.Field == "secondary_opening_hours" && reflect.DeepEqual(withoutOpenNow(old.SecondaryOpeningHours), withoutOpenNow(p.SecondaryOpeningHours)) {
+			continue
+		}
+		if !ignoredFields[change.Field] {
+			fields = append(fields, change.Field)
+		}
+	}
+	if len(fields) > 0 {
+		change := base
+		change.Kind, change.Fields = PlaceUpdated, fields
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// withoutOpenNow returns a copy of hours with OpenNow cleared, since it changes with the time of day rather than with the place.
+func withoutOpenNow(hours []OpeningHours) []OpeningHours {
+	if hours == nil {
+		return nil
+	}
+	out := make([]OpeningHours, len(hours))
+	for i, h := range hours {
+		h.OpenNow = false
+		out[i] = h
+	}
+	return out
+}
+
+// closed reports whether the place is closed, temporarily or permanently.
+func (p *PlaceDetails) closed() bool {
+	return p.BusinessStatus == ClosedTemporarily || p.BusinessStatus == ClosedPermanently || p.PermanentlyClosed
+}
+
+// status returns the business status of the place, falling back to the deprecated permanently_closed flag and treating a place without a status as operational.
+func (p *PlaceDetails) status() BusinessStatus {
+	switch {
+	case p.BusinessStatus != "":
+		return p.BusinessStatus
+	case p.PermanentlyClosed:
+		return ClosedPermanently
+	}
+	return Operational
+}
+
+func abs(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func (r *ChangeReport) add(change Change) {
+	r.Changes = append(r.Changes, change)
+	r.Summary[change.Kind]++
+}
+
+// Len returns the number of changes in the report.
+func (r *ChangeReport) Len() int {
+	return len(r.Changes)
+}
+
+// WriteJSON writes the report to w as indented JSON.
+func (r *ChangeReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteMarkdown writes the report to w as a Markdown document with a table of the number of changes of each kind, followed by a section listing the changes of each kind.
+func (r *ChangeReport) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Place changes\n\n%d places before, %d after.\n\n", r.Before, r.After)
+	b.WriteString("| Change | Places |\n| --- | ---: |\n")
+	for _, kind := range changeKinds {
+		fmt.Fprintf(&b, "| %s | %d |\n", kind.title(), r.Summary[kind])
+	}
+
+	for _, kind := range changeKinds {
+		if r.Summary[kind] == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", kind.title())
+		for _, change := range r.Changes {
+			if change.Kind == kind {
+				fmt.Fprintf(&b, "- %s\n", change.markdown())
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// title returns the kind as a heading, e.g. "Hours changed".
+func (k ChangeKind) title() string {
+	s := strings.Replace(string(k), "_", " ", -1)
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// markdown describes the change as a line of Markdown.
+func (c Change) markdown() string {
+	line := fmt.Sprintf("**%s** (`%s`)", markdownEscaper.Replace(c.Name), c.PlaceID)
+	if c.PreviousID != "" {
+		line += fmt.Sprintf(", previously `%s`", c.PreviousID)
+	}
+	switch c.Kind {
+	case PlaceClosed, PlaceReopened, RatingChanged:
+		line += fmt.Sprintf(": %v → %v", c.Old, c.New)
+	case PlaceRenamed:
+		line += fmt.Sprintf(": formerly %s", markdownEscaper.Replace(fmt.Sprint(c.Old)))
+	case PlaceUpdated:
+		line += ": " + strings.Join(c.Fields, ", ")
+	}
+	return line
+}
+
+// markdownEscaper escapes the characters in place names that Markdown would treat as formatting.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`, "|", `\|`,
+)
@@ -0,0 +1,178 @@
+package places
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func snapshots() (before, after []PlaceDetails) {
+	morning := []Period{{Open: DayTime{Day: 1, Time: "0800"}, Close: DayTime{Day: 1, Time: "1200"}}}
+	allDay := []Period{{Open: DayTime{Day: 1, Time: "0800"}, Close: DayTime{Day: 1, Time: "1800"}}}
+	before = []PlaceDetails{
+		{PlaceID: "a", Name: "Cafe", Rating: 4.0, Website: "https://cafe.example", OpeningHours: OpeningHours{Periods: morning}},
+		{PlaceID: "b", Name: "Bar"},
+		{PlaceID: "app1", Scope: ScopeApp, Name: "Shop"},
+		{PlaceID: "d", Name: "Diner", Rating: 4.5, BusinessStatus: Operational},
+	}
+	after = []PlaceDetails{
+		{PlaceID: "a", Name: "Cafe Nero", Rating: 4.1, Website: "https://nero.example", OpeningHours: OpeningHours{Periods: allDay}},
+		{PlaceID: "d", Name: "Diner", Rating: 3.9, BusinessStatus: ClosedPermanently},
+		{PlaceID: "g1", Scope: ScopeGoogle, Name: "Shop", AltIDs: []AltID{{PlaceID: "app1", Scope: ScopeApp}}},
+		{PlaceID: "e", Name: "Eatery"},
+	}
+	return before, after
+}
+
+func TestComparerCompare(t *testing.T) {
+	before, after := snapshots()
+	for _, test := range []struct {
+		Name      string
+		Threshold float64
+		Want      []string
+	}{
+		{
+			Name:      "rating threshold",
+			Threshold: 0.3,
+			Want: []string{
+				"renamed a Cafe Cafe Nero",
+				"hours_changed a",
+				"updated a [website]",
+				"closed d OPERATIONAL CLOSED_PERMANENTLY",
+				"rating_changed d 4.5 3.9",
+				"added e",
+				"removed b",
+			},
+		},
+		{
+			Name: "every rating change",
+			Want: []string{
+				"renamed a Cafe Cafe Nero",
+				"hours_changed a",
+				"rating_changed a 4 4.1",
+				"updated a [website]",
+				"closed d OPERATIONAL CLOSED_PERMANENTLY",
+				"rating_changed d 4.5 3.9",
+				"added e",
+				"removed b",
+			},
+		},
+	} {
+		report := Comparer{RatingThreshold: test.Threshold}.Compare(before, after)
+		var got []string
+		for _, c := range report.Changes {
+			s := fmt.Sprintf("%s %s", c.Kind, c.PlaceID)
+			switch c.Kind {
+			case PlaceRenamed, PlaceClosed, RatingChanged:
+				s += fmt.Sprintf(" %v %v", c.Old, c.New)
+			case PlaceUpdated:
+				s += fmt.Sprintf(" %v", c.Fields)
+			}
+			got = append(got, s)
+		}
+		if strings.Join(got, "\n") != strings.Join(test.Want, "\n") {
+			t.Errorf("%s: Compare() =\n%s\nwant\n%s", test.Name, strings.Join(got, "\n"), strings.Join(test.Want, "\n"))
+		}
+	}
+}
+
+func TestComparerCompareStatus(t *testing.T) {
+	for _, test := range []struct {
+		Name          string
+		Before, After PlaceDetails
+		Want          string
+	}{
+		{"reopened", PlaceDetails{PermanentlyClosed: true}, PlaceDetails{}, "[reopened CLOSED_PERMANENTLY OPERATIONAL]"},
+		{"closed temporarily", PlaceDetails{BusinessStatus: Operational}, PlaceDetails{BusinessStatus: ClosedTemporarily}, "[closed OPERATIONAL CLOSED_TEMPORARILY]"},
+		{"temporarily then permanently", PlaceDetails{BusinessStatus: ClosedTemporarily}, PlaceDetails{BusinessStatus: ClosedPermanently}, "[closed CLOSED_TEMPORARILY CLOSED_PERMANENTLY]"},
+		{"legacy flag", PlaceDetails{PermanentlyClosed: true}, PlaceDetails{BusinessStatus: ClosedPermanently}, "[]"},
+		{"unchanged", PlaceDetails{BusinessStatus: ClosedTemporarily}, PlaceDetails{BusinessStatus: ClosedTemporarily}, "[]"},
+	} {
+		test.Before.PlaceID, test.Before.Name = "a", "Inn"
+		test.After.PlaceID, test.After.Name = "a", "Inn"
+		report := Comparer{}.Compare([]PlaceDetails{test.Before}, []PlaceDetails{test.After})
+		var got []string
+		for _, c := range report.Changes {
+			got = append(got, fmt.Sprintf("%s %v %v", c.Kind, c.Old, c.New))
+		}
+		if fmt.Sprint(got) != test.Want {
+			t.Errorf("%s: Compare() = %v, want %s", test.Name, got, test.Want)
+		}
+	}
+}
+
+func TestComparerCompareSecondaryHours(t *testing.T) {
+	driveThrough := func(openNow bool, close string) PlaceDetails {
+		return PlaceDetails{PlaceID: "a", Name: "Burgers", SecondaryOpeningHours: []OpeningHours{{
+			Type:    "DRIVE_THROUGH",
+			OpenNow: openNow,
+			Periods: []Period{{Open: DayTime{Day: 1, Time: "0700"}, Close: DayTime{Day: 1, Time: close}}},
+		}}}
+	}
+	for _, test := range []struct {
+		Name          string
+		Before, After PlaceDetails
+		Want          int
+	}{
+		{"open now", driveThrough(false, "2200"), driveThrough(true, "2200"), 0},
+		{"periods", driveThrough(true, "2200"), driveThrough(true, "2300"), 1},
+	} {
+		report := Comparer{}.Compare([]PlaceDetails{test.Before}, []PlaceDetails{test.After})
+		if report.Len() != test.Want {
+			t.Errorf("%s: Compare() = %+v, want %d changes", test.Name, report.Changes, test.Want)
+		}
+	}
+}
+
+func TestChangeReportWriteJSON(t *testing.T) {
+	before, after := snapshots()
+	report := Comparer{RatingThreshold: 0.3}.Compare(before, after)
+
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Before, After int
+		Summary       map[string]int
+		Changes       []map[string]interface{}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Before != 4 || decoded.After != 4 || decoded.Summary["added"] != 1 || len(decoded.Changes) != 7 {
+		t.Errorf("WriteJSON() = %s", buf.String())
+	}
+	if c := decoded.Changes[0]; c["kind"] != "renamed" || c["old"] != "Cafe" || c["new"] != "Cafe Nero" {
+		t.Errorf("first change = %v", c)
+	}
+}
+
+func TestChangeReportWriteMarkdown(t *testing.T) {
+	before, after := snapshots()
+	after[3].Name = "Eat | Drink *now*"
+	report := Comparer{RatingThreshold: 0.3}.Compare(before, after)
+
+	var buf bytes.Buffer
+	if err := report.WriteMarkdown(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"4 places before, 4 after.",
+		"| Hours changed | 1 |",
+		"| Reopened | 0 |",
+		"## Closed\n\n- **Diner** (`d`): OPERATIONAL → CLOSED_PERMANENTLY\n",
+		"- **Cafe Nero** (`a`): formerly Cafe\n",
+		"- **Cafe Nero** (`a`): website\n",
+		"- **Eat \\| Drink \\*now\\*** (`e`)\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("WriteMarkdown() does not contain %q:\n%s", want, buf.String())
+		}
+	}
+	if strings.Contains(buf.String(), "## Reopened") {
+		t.Errorf("WriteMarkdown() has a section for a kind without changes:\n%s", buf.String())
+	}
+}
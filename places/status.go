@@ -14,6 +14,24 @@ func (e *apiError) Error() string {
 	return e.Status
 }
 
+// httpError is returned when the server responds with a non-200 HTTP status.
+type httpError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("bad resp %d: %s", e.StatusCode, e.Body)
+}
+
+// IsServerError returns true if the error indicates that the server responded with a 5xx HTTP status.
+func IsServerError(err error) bool {
+	if e, ok := err.(*httpError); ok {
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
 // IsUnknown returns true if the error indicates a server-side error and trying again may be successful.
 func IsUnknown(err error) bool {
 	if e, ok := err.(*apiError); ok {
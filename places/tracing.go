@@ -0,0 +1,135 @@
+package places
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var errInvalidTraceparent = errors.New("invalid traceparent header")
+
+// A Tracer starts spans around the work done by a Service: every call (places.<endpoint>), every HTTP attempt made for it (places.attempt) and every page fetched by Pages (places.page). Implementations are typically thin adapters around a tracing library and must be safe for concurrent use.
+type Tracer interface {
+	// Start starts a span that is a child of any span in ctx and returns a context containing the new span.
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// A Span is a single traced operation.
+type Span interface {
+	// SetAttributes annotates the span, e.g. with the status and number of results once they are known.
+	SetAttributes(attrs ...Attribute)
+	// End finishes the span. err is the error the operation failed with, or nil.
+	End(err error)
+}
+
+// A SpanContextCarrier is a Span that can be propagated to other services. When the span started for an HTTP attempt implements it, the request carries a W3C traceparent header.
+type SpanContextCarrier interface {
+	SpanContext() SpanContext
+}
+
+// Attribute is a key/value pair annotating a span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Attr returns an Attribute.
+func Attr(key string, value interface{}) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// NoopTracer is the Tracer used when none is set. Its spans do nothing.
+type NoopTracer struct{}
+
+// Start implements Tracer.
+func (NoopTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(attrs ...Attribute) {}
+func (noopSpan) End(err error)                    {}
+
+func (s *Service) startSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	if s.tracer == nil {
+		return NoopTracer{}.Start(ctx, name, attrs...)
+	}
+	return s.tracer.Start(ctx, name, attrs...)
+}
+
+// SpanContext identifies a span across process boundaries, as described by the W3C Trace Context recommendation.
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	// Flags holds the trace flags. The lowest bit means the trace is sampled.
+	Flags byte
+}
+
+// IsValid reports whether neither the trace ID nor the span ID is all zeroes.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// Sampled reports whether the sampled flag is set.
+func (sc SpanContext) Sampled() bool {
+	return sc.Flags&1 == 1
+}
+
+// Traceparent formats the span context as the value of a traceparent header.
+func (sc SpanContext) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-%02x", hex.EncodeToString(sc.TraceID[:]), hex.EncodeToString(sc.SpanID[:]), sc.Flags)
+}
+
+// Inject sets the traceparent header of h to the span context, if it is valid.
+func (sc SpanContext) Inject(h http.Header) {
+	if sc.IsValid() {
+		h.Set("traceparent", sc.Traceparent())
+	}
+}
+
+// ParseTraceparent parses the value of a traceparent header.
+func ParseTraceparent(value string) (SpanContext, error) {
+	var sc SpanContext
+
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return sc, errInvalidTraceparent
+	}
+	// Version ff is forbidden and version 00 has exactly four fields. Later versions may append fields.
+	if parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return sc, errInvalidTraceparent
+	}
+	if strings.ToLower(value) != value {
+		return sc, errInvalidTraceparent
+	}
+
+	var version, flags [1]byte
+	if _, err := hex.Decode(version[:], []byte(parts[0])); err != nil {
+		return sc, errInvalidTraceparent
+	}
+	if _, err := hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return sc, errInvalidTraceparent
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return sc, errInvalidTraceparent
+	}
+	if _, err := hex.Decode(flags[:], []byte(parts[3])); err != nil {
+		return sc, errInvalidTraceparent
+	}
+	sc.Flags = flags[0]
+
+	if !sc.IsValid() {
+		return SpanContext{}, errInvalidTraceparent
+	}
+	return sc, nil
+}
+
+// Extract returns the span context in the traceparent header of h, if there is a valid one.
+func Extract(h http.Header) (SpanContext, bool) {
+	sc, err := ParseTraceparent(h.Get("traceparent"))
+	return sc, err == nil
+}
@@ -0,0 +1,186 @@
+package places
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordedSpan struct {
+	name   string
+	parent string
+	attrs  map[string]interface{}
+	err    error
+	ended  bool
+}
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+type spanKey struct{}
+
+func (r *recordingTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	parent, _ := ctx.Value(spanKey{}).(*recordingSpan)
+	span := &recordingSpan{tracer: r, rec: &recordedSpan{name: name, attrs: map[string]interface{}{}}}
+	if parent != nil {
+		span.rec.parent = parent.rec.name
+	}
+	span.SetAttributes(attrs...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, span.rec)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func (r *recordingTracer) summary() string {
+	var lines []string
+	for _, s := range r.spans {
+		lines = append(lines, fmt.Sprintf("%s<%s %v", s.name, s.parent, s.attrs))
+	}
+	return strings.Join(lines, "\n")
+}
+
+type recordingSpan struct {
+	tracer *recordingTracer
+	rec    *recordedSpan
+}
+
+func (s *recordingSpan) SetAttributes(attrs ...Attribute) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	for _, a := range attrs {
+		s.rec.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *recordingSpan) End(err error) {
+	s.rec.err = err
+	s.rec.ended = true
+}
+
+func (s *recordingSpan) SpanContext() SpanContext {
+	return SpanContext{
+		TraceID: [16]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  [8]byte{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		Flags:   1,
+	}
+}
+
+func TestParseTraceparent(t *testing.T) {
+	for _, test := range []struct {
+		Value string
+		Valid bool
+	}{
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true},
+		{"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future", true},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", false},
+		{"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false},
+		{"00-00000000000000000000000000000000-00f067aa0ba902b7-01", false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false},
+		{"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01", false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e47zz-00f067aa0ba902b7-01", false},
+		{"", false},
+	} {
+		sc, err := ParseTraceparent(test.Value)
+		if (err == nil) != test.Valid {
+			t.Errorf("ParseTraceparent(%q) = %v, want valid %v", test.Value, err, test.Valid)
+		}
+		if err == nil && test.Value[:2] == "00" && sc.Traceparent() != test.Value {
+			t.Errorf("ParseTraceparent(%q).Traceparent() = %q", test.Value, sc.Traceparent())
+		}
+	}
+}
+
+func TestServiceTracer(t *testing.T) {
+	var traceparent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		fmt.Fprint(w, `{"status": "OK", "result": {"place_id": "ChIJLU7jZClu5kcR4PcOOO6p3I0"}}`)
+	}))
+	defer ts.Close()
+
+	tracer := &recordingTracer{}
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL), WithTracer(tracer))
+
+	if _, err := service.Details("ChIJLU7jZClu5kcR4PcOOO6p3I0").Context(context.Background()).Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "places.details< map[places.endpoint:details places.result_count:1 places.status:OK]\n" +
+		"places.attempt<places.details map[places.attempt:1 places.endpoint:details places.status:OK]"
+	if got := tracer.summary(); got != want {
+		t.Errorf("spans =\n%s\nwant\n%s", got, want)
+	}
+	for _, s := range tracer.spans {
+		if !s.ended {
+			t.Errorf("span %s was not ended", s.name)
+		}
+	}
+	if traceparent != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" {
+		t.Errorf("traceparent header = %q", traceparent)
+	}
+}
+
+func TestNearbyCallPages(t *testing.T) {
+	defer func(d time.Duration) { pageTokenDelay = d }(pageTokenDelay)
+	pageTokenDelay = time.Millisecond
+
+	early := true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("pagetoken") {
+		case "":
+			fmt.Fprint(w, `{"status": "OK", "results": [{"name": "a"}, {"name": "b"}], "next_page_token": "page2"}`)
+		case "page2":
+			// The first request for a fresh token is rejected, as the API does before the token is valid.
+			if early {
+				early = false
+				fmt.Fprint(w, `{"status": "INVALID_REQUEST"}`)
+				return
+			}
+			fmt.Fprint(w, `{"status": "OK", "results": [{"name": "c"}]}`)
+		}
+	}))
+	defer ts.Close()
+
+	tracer := &recordingTracer{}
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL), WithTracer(tracer))
+
+	call := service.Nearby(-33.8670522, 151.1957362)
+	call.Radius = 500
+
+	var names []string
+	err := call.Pages(context.Background(), func(resp *SearchResponse) error {
+		for _, result := range resp.Results {
+			names = append(names, result.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(names) != "[a b c]" {
+		t.Errorf("NearbyCall{}.Pages() results = %v, want [a b c]", names)
+	}
+	if call.PageToken != "" {
+		t.Errorf("NearbyCall{}.Pages() left PageToken = %q", call.PageToken)
+	}
+
+	var pages []string
+	for _, s := range tracer.spans {
+		if s.name == "places.page" {
+			pages = append(pages, fmt.Sprint(s.attrs["places.page_index"], ":", s.attrs["places.result_count"]))
+		}
+	}
+	if fmt.Sprint(pages) != "[0:2 1:1]" {
+		t.Errorf("page spans = %v, want [0:2 1:1]", pages)
+	}
+}
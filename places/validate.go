@@ -0,0 +1,121 @@
+package places
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	errNegativeRadius       = errors.New("radius must not be negative")
+	errRadiusWithDistance   = errors.New("radius must not be included when RankByDistance is specified")
+	errInvalidRankBy        = errors.New("rankby must be prominence or distance")
+	errInvalidPriceLevel    = errors.New("price level must be between 0 and 4")
+	errInvalidPriceRange    = errors.New("minprice must not be greater than maxprice")
+	errTextTooLong          = errors.New("the value is too long")
+	errPageTokenUnsupported = errors.New("this search does not return pages, so a page token cannot be used")
+	errInvalidByRadar       = errors.New("one or more of keyword or type is required")
+)
+
+// maxTextLength is the longest keyword, name or query, in characters, that the client will send. The API does not document a limit, but longer values make very long URLs and never match anything useful.
+const maxTextLength = 256
+
+// ValidationError describes a problem with a single request parameter. Field is the name of the parameter as it is sent to the API, e.g. "radius" or "minprice".
+type ValidationError struct {
+	Field string
+	Err   error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors is returned by Do when one or more request parameters are invalid. No request is sent in that case.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap lets errors.Is and errors.As inspect each of the field errors.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// IsValidation returns true if the error indicates that the request was rejected before it was sent because one of its parameters is invalid.
+func IsValidation(err error) bool {
+	var e *ValidationError
+	return errors.As(err, &e)
+}
+
+// validator collects the field errors found while checking a call.
+type validator struct {
+	errs ValidationErrors
+}
+
+// check records err against field if it is not nil.
+func (v *validator) check(field string, err error) {
+	if err != nil {
+		v.errs = append(v.errs, &ValidationError{Field: field, Err: err})
+	}
+}
+
+// err returns the collected errors, or nil if there are none.
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+func (v *validator) radius(radius float64) {
+	switch {
+	case radius < 0:
+		v.check("radius", errNegativeRadius)
+	case radius > maximumRadius:
+		v.check("radius", errRadiusIsTooGreat)
+	}
+}
+
+func (v *validator) prices(min, max *PriceLevel) {
+	v.check("minprice", validatePriceLevel(min))
+	v.check("maxprice", validatePriceLevel(max))
+	if min != nil && max != nil && *min > *max {
+		v.check("minprice", errInvalidPriceRange)
+	}
+}
+
+func (v *validator) text(field, value string) {
+	if utf8.RuneCountInString(value) > maxTextLength {
+		v.check(field, errTextTooLong)
+	}
+}
+
+func (v *validator) featureType(t FeatureType) {
+	switch {
+	case t == "":
+	case !t.Valid():
+		v.check("type", errUnknownType)
+	case !t.Searchable():
+		v.check("type", errResponseOnlyType)
+	}
+}
+
+func validatePriceLevel(p *PriceLevel) error {
+	if p != nil && (*p < Free || *p > VeryExpensive) {
+		return errInvalidPriceLevel
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package places
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidationErrors(t *testing.T) {
+	call := dummyService.Nearby(95, 10)
+	call.Radius = maximumRadius + 1
+	call.MinPrice = priceLevel(VeryExpensive)
+	call.MaxPrice = priceLevel(Free)
+
+	err := call.validate()
+	if !IsValidation(err) {
+		t.Fatalf("NearbyCall{}.validate() = %v, want a validation error", err)
+	}
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("NearbyCall{}.validate() = %T, want ValidationErrors", err)
+	}
+	var fields []string
+	for _, e := range errs {
+		fields = append(fields, e.Field)
+	}
+	if got, want := fmt.Sprint(fields), "[location radius minprice]"; got != want {
+		t.Errorf("ValidationErrors fields = %v, want %v", got, want)
+	}
+	for _, want := range []error{errLatitudeOutOfRange, errRadiusIsTooGreat, errInvalidPriceRange} {
+		if !errors.Is(err, want) {
+			t.Errorf("errors.Is(%v, %v) = false", err, want)
+		}
+	}
+
+	want := "location: latitude must be between -90 and 90 degrees; radius: radius is too large, a maximum of 50 000 meters is allowed; minprice: minprice must not be greater than maxprice"
+	if err.Error() != want {
+		t.Errorf("ValidationErrors.Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestIsValidation(t *testing.T) {
+	for _, test := range []struct {
+		Err  error
+		Want bool
+	}{
+		{nil, false},
+		{errors.New("other"), false},
+		{&apiError{Status: "INVALID_REQUEST"}, false},
+		{&ValidationError{Field: "radius", Err: errNegativeRadius}, true},
+		{ValidationErrors{{Field: "radius", Err: errNegativeRadius}}, true},
+	} {
+		if got := IsValidation(test.Err); got != test.Want {
+			t.Errorf("IsValidation(%v) = %v, want %v", test.Err, got, test.Want)
+		}
+	}
+}
+
+func TestValidationBeforeRequest(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer ts.Close()
+
+	service := NewService(http.DefaultClient, "key", WithBaseURL(ts.URL))
+
+	nearby := service.Nearby(-33.8670522, 151.1957362)
+	nearby.RankBy = RankByDistance
+	nearby.Radius = 500
+	if _, err := nearby.Do(); !errors.Is(err, errRadiusWithDistance) {
+		t.Errorf("NearbyCall{}.Do() = %v, want %v", err, errRadiusWithDistance)
+	}
+
+	radar := service.RadarSearch(500, -33.8670522, 151.1957362)
+	if _, err := radar.Do(); !errors.Is(err, errInvalidByRadar) {
+		t.Errorf("RadarSearchCall{}.Do() = %v, want %v", err, errInvalidByRadar)
+	}
+
+	if _, err := service.Details("").Do(); !errors.Is(err, errMissingPlaceID) {
+		t.Errorf("DetailsCall{}.Do() = %v, want %v", err, errMissingPlaceID)
+	}
+
+	if requests != 0 {
+		t.Errorf("invalid calls sent %d requests, want 0", requests)
+	}
+}